@@ -1,130 +1,475 @@
-package solvencyanalytics
+package algorithmictask
 
 import (
 	"errors"
+	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
+
+	"solvencyanalytics/algorithmictask/subsequence"
 )
 
 var (
-	errHaystackEmpty   = errors.New("haystack is empty")
-	errNeedleEmpty     = errors.New("needle is empty")
-	errHaystackShorter = errors.New("haystack is shorter")
+	ErrHaystackEmpty   = errors.New("haystack is empty")
+	ErrNeedleEmpty     = errors.New("needle is empty")
+	ErrHaystackShorter = errors.New("haystack is shorter")
+
+	ErrPatternUnclosedClass     = errors.New("pattern class is not closed")
+	ErrPatternEmptyClass        = errors.New("pattern class matches no digit")
+	ErrPatternReversedRange     = errors.New("pattern range ends below its start")
+	ErrPatternInvalidDigit      = errors.New("pattern character is not a digit")
+	ErrPatternMisplacedOptional = errors.New("pattern ? does not follow an element")
+
+	ErrNeedleNotDigit  = errors.New("needle element is not a single digit")
+	ErrNeedleNotDigits = errors.New("needle element is not a sequence of digits")
+	ErrNeedleNegative  = errors.New("needle element is negative")
+	ErrInvalidBase     = errors.New("base must be between 2 and 36")
+	ErrInvalidSignMode = errors.New("invalid sign mode")
 
-	errDistanceTooLarge       = errors.New("maxDistance too large")
-	errDistanceMustBePositive = errors.New("maxDistance must be a positive number")
+	ErrDistanceTooLarge       = errors.New("maxDistance too large")
+	ErrDistanceMustBePositive = errors.New("maxDistance must be a positive number")
 )
 
-func findFirstOccurance(haystack, needle []int) ([]int, error) {
-	results, err := findAllOccurances(haystack, needle)
-	if err != nil {
+// Search runs needle queries against a single haystack. By default a needle element matches a
+// haystack element when the decimal (or WithBase) form of the element contains the needle
+// element as a substring, so 54 matches 1543. WithSingleDigitNeedle restricts the needle to
+// single digits, and WithSignMode defines how negative values are matched.
+type Search struct {
+	haystack     elements
+	notation     notation
+	singleDigits bool
+	indexed      bool
+	index        *HaystackIndex
+	workers      int
+	limits       Limits
+}
+
+type Option func(*Search)
+
+// WithIndex builds a HaystackIndex once, so repeated queries jump between the matching
+// elements instead of scanning the haystack.
+func WithIndex() Option {
+	return func(s *Search) {
+		s.indexed = true
+	}
+}
+
+// WithSingleDigitNeedle rejects needle elements which are not a single digit with
+// ErrNeedleNotDigit.
+func WithSingleDigitNeedle() Option {
+	return func(s *Search) {
+		s.singleDigits = true
+	}
+}
+
+// WithBase renders the haystack elements and the needle in the given base between 2 and 36,
+// with the digits above 9 written as letters, so the needle 0xA matches "a" in 0x1A3.
+func WithBase(base int) Option {
+	return func(s *Search) {
+		s.notation.base = base
+	}
+}
+
+type SignMode int
+
+const (
+	// SignAbsolute matches the digits of the absolute value of the haystack elements, so -154
+	// contains 5 and 15. Negative needle elements are rejected with ErrNeedleNegative.
+	SignAbsolute SignMode = iota
+	// SignMatched matches non-negative needle elements against the digits of non-negative
+	// haystack elements, and negative needle elements against the digits of negative ones, so
+	// -154 contains -5 and -15, but 5 does not match -45 and -4 does not match 45.
+	SignMatched
+)
+
+// WithSignMode sets how negative values are matched, SignAbsolute is the default.
+func WithSignMode(mode SignMode) Option {
+	return func(s *Search) {
+		s.notation.sign = mode
+	}
+}
+
+// NewSearch returns the error of an invalid option.
+func NewSearch(haystack []int, opts ...Option) (*Search, error) {
+	return newSearch(intElements(haystack), opts)
+}
+
+// NewBigSearch searches a haystack of arbitrary-precision integers. It returns
+// ErrInvalidElement for nil elements.
+func NewBigSearch(haystack []*big.Int, opts ...Option) (*Search, error) {
+	return newSearch(bigElements(haystack), opts)
+}
+
+// NewStringSearch searches a haystack of digit strings in the search base with an optional
+// leading minus sign. The strings are matched as they are, so leading zeros count as digits. It
+// returns ErrInvalidElement for anything else.
+func NewStringSearch(haystack []string, opts ...Option) (*Search, error) {
+	lower := make(stringElements, len(haystack))
+	for i, number := range haystack {
+		lower[i] = strings.ToLower(number)
+	}
+
+	return newSearch(lower, opts)
+}
+
+func newSearch(haystack elements, opts []Option) (*Search, error) {
+	s := &Search{haystack: haystack, notation: notation{base: 10}}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.validate(); err != nil {
 		return nil, err
 	}
 
-	if len(results) < 1 {
-		return []int{}, nil
+	if s.indexed {
+		s.index = newHaystackIndex(haystack, s.notation)
 	}
 
-	return results[0], nil
+	return s, nil
 }
 
-func findFirstOccuranceWithMaxDistanceLimit(haystack, needle []int, maxDistance int) ([]int, error) {
-	if maxDistance <= 0 {
-		return nil, errDistanceMustBePositive
+func (s *Search) validate() error {
+	if err := s.notation.validate(); err != nil {
+		return err
+	}
+
+	if err := s.haystack.validate(s.notation.base); err != nil {
+		return err
+	}
+
+	if s.workers < 0 {
+		return wrapErr(ErrInvalidParallelism, strconv.Itoa(s.workers))
+	}
+
+	return s.limits.validate()
+}
+
+// Index returns the index built by WithIndex, or nil.
+func (s *Search) Index() *HaystackIndex {
+	return s.index
+}
+
+// First returns the lowest indexes of the haystack containing the digits of the needle in order.
+func (s *Search) First(needle []int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
 	}
 
-	if maxDistance > len(haystack) {
-		return nil, errDistanceTooLarge
+	return findFirstOccurance(s.matcher(), elements)
+}
+
+// FirstWithMaxDistance is like First, but the distance between the first and the last index
+// must not exceed maxDistance.
+func (s *Search) FirstWithMaxDistance(needle []int, maxDistance int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
 	}
 
-	results, err := findAllOccurances(haystack, needle)
+	return findFirstOccuranceWithMaxDistanceLimit(s.matcher(), elements, maxDistance)
+}
+
+// FirstWithMinDistance is like First, but the distance between the first and the last index
+// is the smallest possible.
+func (s *Search) FirstWithMinDistance(needle []int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(results) < 1 {
-		return []int{}, nil
+	return findFirstOccuranceWithMinimumPossibleDistance(s.matcher(), elements)
+}
+
+// FirstStrings is like First, but the needle elements are digit strings, so leading zeros
+// are kept: "007" only matches elements containing 007.
+func (s *Search) FirstStrings(needle []string) ([]int, error) {
+	elements, err := s.normalizeNeedle(needle)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, res := range results {
-		distance := res[len(needle)-1] - res[0]
-		if distance <= maxDistance {
-			return res, nil
-		}
+	return findFirstOccurance(s.matcher(), elements)
+}
+
+func (s *Search) FirstStringsWithMaxDistance(needle []string, maxDistance int) ([]int, error) {
+	elements, err := s.normalizeNeedle(needle)
+	if err != nil {
+		return nil, err
 	}
 
-	return []int{}, nil
+	return findFirstOccuranceWithMaxDistanceLimit(s.matcher(), elements, maxDistance)
 }
 
-func findFirstOccuranceWithMinimumPossibleDistance(haystack, needle []int) ([]int, error) {
-	results, err := findAllOccurances(haystack, needle)
+func (s *Search) FirstStringsWithMinDistance(needle []string) ([]int, error) {
+	elements, err := s.normalizeNeedle(needle)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(results) < 1 {
-		return []int{}, nil
+	return findFirstOccuranceWithMinimumPossibleDistance(s.matcher(), elements)
+}
+
+func (s *Search) needleStrings(needle []int) ([]string, error) {
+	for _, digit := range needle {
+		if digit < 0 && s.notation.sign == SignAbsolute {
+			return nil, wrapErr(ErrNeedleNegative, strconv.Itoa(digit))
+		}
+
+		if s.singleDigits && (digit <= -s.notation.base || digit >= s.notation.base) {
+			return nil, wrapErr(ErrNeedleNotDigit, strconv.Itoa(digit))
+		}
 	}
 
-	minDistance := len(haystack)
-	var minDistanceIdx int
-	for i, res := range results {
-		distance := res[len(needle)-1] - res[0]
-		if distance < minDistance {
-			minDistance = distance
-			minDistanceIdx = i
+	return formatNeedle(needle, s.notation.base), nil
+}
+
+func (s *Search) normalizeNeedle(needle []string) ([]string, error) {
+	elements := make([]string, len(needle))
+	for i, element := range needle {
+		elements[i] = strings.ToLower(element)
+		digits, negative := strings.CutPrefix(elements[i], "-")
+		if negative && s.notation.sign == SignAbsolute {
+			return nil, wrapErr(ErrNeedleNegative, element)
+		}
+
+		if s.singleDigits && len(digits) != 1 {
+			return nil, wrapErr(ErrNeedleNotDigit, element)
+		}
+
+		if !isDigits(digits, s.notation.base) {
+			return nil, wrapErr(ErrNeedleNotDigits, element)
 		}
 	}
 
-	return results[minDistanceIdx], nil
+	return elements, nil
 }
 
-var findAllOccurances = func(haystack, needle []int) ([][]int, error) {
+func (s *Search) matcher() matcher {
+	if s.index != nil {
+		return s.index
+	}
+
+	return plainHaystack{s.haystack, s.notation}
+}
+
+// matcher tells the search algorithms which haystack elements contain a needle element.
+type matcher interface {
+	len() int
+	contains(i int, element string) bool
+	// next returns the lowest index from the given one containing the element, or len().
+	next(from int, element string) int
+}
+
+type plainHaystack struct {
+	elements
+	notation notation
+}
+
+func (h plainHaystack) contains(i int, element string) bool {
+	return h.notation.contains(h.format(i, h.notation.base), element)
+}
+
+func (h plainHaystack) next(from int, element string) int {
+	for from < h.len() && !h.contains(from, element) {
+		from++
+	}
+
+	return from
+}
+
+func findFirstOccurance(haystack matcher, needle []string) ([]int, error) {
 	if err := validate(haystack, needle); err != nil {
 		return nil, err
 	}
 
-	results := [][]int{}
-	for j := 0; j < len(haystack)-len(needle)+1; j++ {
-		var needleIdx int
-		result := make([]int, 0, len(needle))
-		for i := j; i < len(haystack); i++ {
-			h := haystack[i]
-			if contains(h, needle[needleIdx]) {
-				result = append(result, i)
-				needleIdx++
+	return findFrom(haystack, needle, 0), nil
+}
+
+func findFirstOccuranceWithMaxDistanceLimit(haystack matcher, needle []string, maxDistance int) ([]int, error) {
+	if err := validateDistance(haystack, maxDistance); err != nil {
+		return nil, err
+	}
+
+	occurances, err := findAllOccurances(haystack, needle)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []int{}
+	occurances(func(occurance []int) bool {
+		if occurance[len(occurance)-1]-occurance[0] <= maxDistance {
+			result = occurance
+			return false
+		}
+
+		return true
+	})
+
+	return result, nil
+}
+
+func findFirstOccuranceWithMinimumPossibleDistance(haystack matcher, needle []string) ([]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	return lowestMinimumDistance(haystack, needle), nil
+}
+
+func lowestMinimumDistance(haystack matcher, needle []string) []int {
+	starts := minimumDistanceStarts(haystack, needle, false)
+	if len(starts) == 0 {
+		return []int{}
+	}
+
+	return findFrom(haystack, needle, starts[0])
+}
+
+// minimumDistanceStarts returns the start indexes of the matches with the smallest distance, in
+// ascending order. Unless all is set it stops at the first of them.
+func minimumDistanceStarts(haystack matcher, needle []string, all bool) []int {
+	// starts[k] is the latest index where a match of needle[:k+1] ending at or before the
+	// current index can start, so each needle digit is checked once per haystack element.
+	starts := make([]int, len(needle))
+	for k := range starts {
+		starts[k] = -1
+	}
+
+	last := len(needle) - 1
+	var minStarts []int
+	minDistance := haystack.len()
+	for i := 0; i < haystack.len() && (all || minDistance > last); i++ {
+		for k := last; k >= 0; k-- {
+			if !haystack.contains(i, needle[k]) {
+				continue
+			}
+
+			if k == 0 {
+				starts[k] = i
+			} else if starts[k-1] >= 0 {
+				starts[k] = starts[k-1]
+			} else {
+				continue
 			}
 
-			if needleIdx >= len(needle) {
-				results = append(results, result)
-				j = result[0]
-				break
+			if k != last {
+				continue
+			}
+
+			switch distance := i - starts[k]; {
+			case distance < minDistance:
+				minStarts, minDistance = append(minStarts[:0], starts[k]), distance
+			case distance == minDistance && all:
+				minStarts = append(minStarts, starts[k])
 			}
 		}
 	}
 
-	return results, nil
+	return minStarts
 }
 
-func validate(haystack, needle []int) error {
-	if len(haystack) == 0 {
-		return errHaystackEmpty
+// findAllOccurances yields the lowest match for each start index lazily, so the callers can stop
+// as soon as they found what they need.
+var findAllOccurances = func(haystack matcher, needle []string) (Seq[[]int], error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	return subsequence.All[string](engine{haystack}, needle), nil
+}
+
+func findFrom(haystack matcher, needle []string, start int) []int {
+	return subsequence.From[string](engine{haystack}, needle, start)
+}
+
+// engine exposes a matcher as the haystack of the generic subsequence search.
+type engine struct {
+	matcher
+}
+
+func (e engine) Len() int {
+	return e.len()
+}
+
+func (e engine) Next(from int, element string) int {
+	return e.next(from, element)
+}
+
+func validate(haystack matcher, needle []string) error {
+	if haystack.len() == 0 {
+		return ErrHaystackEmpty
 	}
 
 	if len(needle) == 0 {
-		return errNeedleEmpty
+		return ErrNeedleEmpty
+	}
+
+	if haystack.len() < len(needle) {
+		return ErrHaystackShorter
+	}
+
+	return nil
+}
+
+func validateDistance(haystack matcher, maxDistance int) error {
+	if maxDistance <= 0 {
+		return ErrDistanceMustBePositive
 	}
 
-	if len(haystack) < len(needle) {
-		return errHaystackShorter
+	if maxDistance > haystack.len() {
+		return ErrDistanceTooLarge
 	}
 
 	return nil
 }
 
-func contains(number int, digit int) bool {
-	numberStr := strconv.Itoa(number)
-	digitStr := strconv.Itoa(digit)
+func wrapErr(err error, detail string) error {
+	return fmt.Errorf("%w: %s", err, detail)
+}
+
+func digitStrings(needle []int) []string {
+	return formatNeedle(needle, 10)
+}
+
+func formatNeedle(needle []int, base int) []string {
+	if needle == nil {
+		return nil
+	}
+
+	elements := make([]string, len(needle))
+	for i, digit := range needle {
+		elements[i] = strconv.FormatInt(int64(digit), base)
+	}
+
+	return elements
+}
+
+// isDigits reports whether element is a non-empty sequence of lowercase digits of the base.
+func isDigits(element string, base int) bool {
+	if len(element) == 0 {
+		return false
+	}
+
+	for i := 0; i < len(element); i++ {
+		if digitValue(element[i]) >= base {
+			return false
+		}
+	}
+
+	return true
+}
+
+// digitValue returns the value of a lowercase digit, or 36 for anything else.
+func digitValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + 10
+	}
 
-	return strings.Contains(numberStr, digitStr)
+	return 36
 }
@@ -1,10 +1,15 @@
-package solvencyanalytics
+package algorithmictask
 
 import (
 	"errors"
+	"math/big"
+	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"solvencyanalytics/algorithmictask/subsequence"
 )
 
 var errFindAllOccurances = errors.New("findAllOccurances error")
@@ -33,23 +38,24 @@ func TestFindAllOccurances(t *testing.T) {
 			name:          "haystack_is_empty",
 			haystack:      nil,
 			needle:        []int{0},
-			expectedError: errHaystackEmpty,
+			expectedError: ErrHaystackEmpty,
 		},
 		{
 			name:          "needle_is_empty",
 			haystack:      []int{0},
 			needle:        nil,
-			expectedError: errNeedleEmpty,
+			expectedError: ErrNeedleEmpty,
 		},
 		{
 			name:          "haystack_is_shorter",
 			haystack:      []int{123},
 			needle:        []int{1, 2},
-			expectedError: errHaystackShorter,
+			expectedError: ErrHaystackShorter,
 		},
 	} {
 		t.Run(s.name, func(t *testing.T) {
-			actual, actualError := findAllOccurances(s.haystack, s.needle)
+			occurances, actualError := findAllOccurances(plainHaystack{intElements(s.haystack), notation{base: 10}}, digitStrings(s.needle))
+			actual := collect(occurances)
 			assert.Equal(t, s.expected, actual)
 			if s.expected != nil {
 				assert.ErrorIs(t, s.expectedError, actualError)
@@ -58,12 +64,39 @@ func TestFindAllOccurances(t *testing.T) {
 	}
 }
 
+func collect(occurances Seq[[]int]) [][]int {
+	if occurances == nil {
+		return nil
+	}
+
+	results := [][]int{}
+	occurances(func(occurance []int) bool {
+		results = append(results, occurance)
+		return true
+	})
+
+	return results
+}
+
+// fakeOccurances yields the given results and panics when it is consumed after a nil one.
+func fakeOccurances(results ...[]int) Seq[[]int] {
+	return func(yield func([]int) bool) {
+		for _, result := range results {
+			if result == nil {
+				panic("consumed too far")
+			}
+			if !yield(result) {
+				return
+			}
+		}
+	}
+}
+
 func TestFindFirstOccurance(t *testing.T) {
 	for _, s := range []struct {
 		name                       string
 		haystack, needle, expected []int
 		expectedError              error
-		mockedReturn               func([]int, []int) ([][]int, error)
 	}{
 		{
 			name:     "test_1",
@@ -84,23 +117,26 @@ func TestFindFirstOccurance(t *testing.T) {
 			expected: []int{},
 		},
 		{
-			name: "receiving_error",
-			mockedReturn: func([]int, []int) ([][]int, error) {
-				return nil, errFindAllOccurances
-			},
-			expectedError: errFindAllOccurances,
+			name:     "needle_at_the_end",
+			haystack: []int{1, 1, 1, 1, 2, 1, 3},
+			needle:   []int{2, 3},
+			expected: []int{4, 6},
+		},
+		{
+			name:     "partial_match",
+			haystack: []int{1, 2, 1, 2},
+			needle:   []int{1, 2, 3},
+			expected: []int{},
+		},
+		{
+			name:          "validation_error",
+			haystack:      nil,
+			needle:        []int{1},
+			expectedError: ErrHaystackEmpty,
 		},
 	} {
 		t.Run(s.name, func(t *testing.T) {
-			if s.mockedReturn != nil {
-				bkp := findAllOccurances
-				defer func() {
-					findAllOccurances = bkp
-				}()
-				findAllOccurances = s.mockedReturn
-			}
-
-			actual, actualError := findFirstOccurance(s.haystack, s.needle)
+			actual, actualError := findFirstOccurance(plainHaystack{intElements(s.haystack), notation{base: 10}}, digitStrings(s.needle))
 			assert.Equal(t, s.expected, actual)
 			if s.expected != nil {
 				assert.ErrorIs(t, s.expectedError, actualError)
@@ -115,7 +151,7 @@ func TestFindFirstOccuranceWithMaxDistanceLimit(t *testing.T) {
 		haystack, needle, expected []int
 		maxDistance                int
 		expectedError              error
-		mockedReturn               func([]int, []int) ([][]int, error)
+		mockedReturn               func(matcher, []string) (Seq[[]int], error)
 	}{
 		{
 			name:        "test_1",
@@ -141,28 +177,38 @@ func TestFindFirstOccuranceWithMaxDistanceLimit(t *testing.T) {
 		{
 			name:          "distance_too_large_error",
 			maxDistance:   11,
-			expectedError: errDistanceTooLarge,
+			expectedError: ErrDistanceTooLarge,
 		},
 		{
 			name:          "distance_positive_error_1",
 			maxDistance:   0,
-			expectedError: errDistanceMustBePositive,
+			expectedError: ErrDistanceMustBePositive,
 		},
 		{
 			name:          "distance_positive_error_2",
 			maxDistance:   0,
-			expectedError: errDistanceMustBePositive,
+			expectedError: ErrDistanceMustBePositive,
 		},
 		{
 			name:        "receiving_error",
 			haystack:    []int{0},
 			needle:      []int{0},
 			maxDistance: 1,
-			mockedReturn: func([]int, []int) ([][]int, error) {
+			mockedReturn: func(matcher, []string) (Seq[[]int], error) {
 				return nil, errFindAllOccurances
 			},
 			expectedError: errFindAllOccurances,
 		},
+		{
+			name:        "stops_at_first_within_distance",
+			haystack:    []int{0},
+			needle:      []int{0},
+			maxDistance: 1,
+			mockedReturn: func(matcher, []string) (Seq[[]int], error) {
+				return fakeOccurances([]int{0, 5}, []int{3, 4}, nil), nil
+			},
+			expected: []int{3, 4},
+		},
 	} {
 		t.Run(s.name, func(t *testing.T) {
 			if s.mockedReturn != nil {
@@ -173,7 +219,7 @@ func TestFindFirstOccuranceWithMaxDistanceLimit(t *testing.T) {
 				findAllOccurances = s.mockedReturn
 			}
 
-			actual, actualError := findFirstOccuranceWithMaxDistanceLimit(s.haystack, s.needle, s.maxDistance)
+			actual, actualError := findFirstOccuranceWithMaxDistanceLimit(plainHaystack{intElements(s.haystack), notation{base: 10}}, digitStrings(s.needle), s.maxDistance)
 			assert.Equal(t, s.expected, actual)
 			if s.expected != nil {
 				assert.ErrorIs(t, s.expectedError, actualError)
@@ -187,7 +233,6 @@ func TestFindFirstOccuranceWithMinimumPossibleDistance(t *testing.T) {
 		name                       string
 		haystack, needle, expected []int
 		expectedError              error
-		mockedReturn               func([]int, []int) ([][]int, error)
 	}{
 		{
 			name:     "test_1",
@@ -202,25 +247,32 @@ func TestFindFirstOccuranceWithMinimumPossibleDistance(t *testing.T) {
 			expected: []int{},
 		},
 		{
-			name:     "receiving_error",
-			haystack: []int{0},
-			needle:   []int{0},
-			mockedReturn: func([]int, []int) ([][]int, error) {
-				return nil, errFindAllOccurances
-			},
-			expectedError: errFindAllOccurances,
+			name:     "tie_keeps_the_first",
+			haystack: []int{1, 2, 0, 1, 2},
+			needle:   []int{1, 2},
+			expected: []int{0, 1},
+		},
+		{
+			name:     "single_digit_needle",
+			haystack: []int{3, 4},
+			needle:   []int{4},
+			expected: []int{1},
+		},
+		{
+			name:     "later_start_is_shorter",
+			haystack: []int{1, 0, 0, 1, 2, 0, 3},
+			needle:   []int{1, 2, 3},
+			expected: []int{3, 4, 6},
+		},
+		{
+			name:          "validation_error",
+			haystack:      []int{0},
+			needle:        nil,
+			expectedError: ErrNeedleEmpty,
 		},
 	} {
 		t.Run(s.name, func(t *testing.T) {
-			if s.mockedReturn != nil {
-				bkp := findAllOccurances
-				defer func() {
-					findAllOccurances = bkp
-				}()
-				findAllOccurances = s.mockedReturn
-			}
-
-			actual, actualError := findFirstOccuranceWithMinimumPossibleDistance(s.haystack, s.needle)
+			actual, actualError := findFirstOccuranceWithMinimumPossibleDistance(plainHaystack{intElements(s.haystack), notation{base: 10}}, digitStrings(s.needle))
 			assert.Equal(t, s.expected, actual)
 			if s.expected != nil {
 				assert.ErrorIs(t, s.expectedError, actualError)
@@ -228,3 +280,362 @@ func TestFindFirstOccuranceWithMinimumPossibleDistance(t *testing.T) {
 		})
 	}
 }
+
+func TestFindFirstOccuranceWithMinimumPossibleDistanceOracle(t *testing.T) {
+	for _, c := range (randomCases{n: 500, maxLen: 12, maxNeedle: 12}).generate() {
+		haystack, needle := c.haystack, c.needle
+
+		actual, err := findFirstOccuranceWithMinimumPossibleDistance(plainHaystack{intElements(haystack), notation{base: 10}}, digitStrings(needle))
+		assert.NoError(t, err)
+		assert.Equal(t, bruteForceMinimumPossibleDistance(haystack, needle), actual, "haystack: %v, needle: %v", haystack, needle)
+	}
+}
+
+// randomCases describes the random cases of an oracle test: n haystacks of 1 to maxLen elements
+// in the values range, each with a needle of 1 to maxNeedle elements in the digits range, no
+// longer than the haystack. The ranges are half-open, [0, 1000) and [0, 10) when left zero.
+type randomCases struct {
+	n, maxLen, maxNeedle int
+	values, digits       [2]int
+}
+
+// randomCase is a generated case, with a maxDistance from 1 to len(haystack) and the source for
+// anything else the test draws.
+type randomCase struct {
+	haystack, needle []int
+	maxDistance      int
+	rnd              *rand.Rand
+}
+
+func (r randomCases) generate() []randomCase {
+	values, digits := r.values, r.digits
+	if values == [2]int{} {
+		values = [2]int{0, 1000}
+	}
+	if digits == [2]int{} {
+		digits = [2]int{0, 10}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	draw := func(n int, within [2]int) []int {
+		drawn := make([]int, n)
+		for i := range drawn {
+			drawn[i] = within[0] + rnd.Intn(within[1]-within[0])
+		}
+		return drawn
+	}
+
+	cases := make([]randomCase, r.n)
+	for n := range cases {
+		haystack := draw(1+rnd.Intn(r.maxLen), values)
+		needle := draw(1+rnd.Intn(min(len(haystack), r.maxNeedle)), digits)
+		cases[n] = randomCase{haystack: haystack, needle: needle, maxDistance: 1 + rnd.Intn(len(haystack)), rnd: rnd}
+	}
+
+	return cases
+}
+
+func bruteForceMinimumPossibleDistance(haystack, needle []int) []int {
+	best := []int{}
+	var walk func(result []int, from int)
+	walk = func(result []int, from int) {
+		if len(result) == len(needle) {
+			distance := result[len(result)-1] - result[0]
+			if len(best) == 0 || distance < best[len(best)-1]-best[0] {
+				best = append([]int{}, result...)
+			}
+			return
+		}
+		for i := from; i < len(haystack); i++ {
+			if subsequence.Digits(haystack[i], strconv.Itoa(needle[len(result)])) {
+				walk(append(result, i), i+1)
+			}
+		}
+	}
+	walk(make([]int, 0, len(needle)), 0)
+
+	return best
+}
+
+func TestSearch(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+	needle := []int{6, 5, 4}
+	search := mustSearch(t, haystack)
+
+	actual, err := search.First(needle)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 4}, actual)
+
+	actual, err = search.FirstWithMaxDistance(needle, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{7, 8, 10}, actual)
+
+	actual, err = search.FirstWithMinDistance(needle)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{8, 9, 10}, actual)
+
+	_, err = mustSearch(t, nil).First(needle)
+	assert.True(t, errors.Is(err, ErrHaystackEmpty))
+
+	_, err = search.FirstWithMaxDistance(needle, 0)
+	assert.True(t, errors.Is(err, ErrDistanceMustBePositive))
+
+	_, err = search.FirstWithMinDistance(nil)
+	assert.True(t, errors.Is(err, ErrNeedleEmpty))
+}
+
+func BenchmarkFindFirstOccurance(b *testing.B) {
+	for _, size := range []int{1_000, 10_000, 100_000, 1_000_000} {
+		haystack := make([]int, size)
+		for i := range haystack {
+			haystack[i] = 111
+		}
+		haystack[size-2] = 6
+		haystack[size-1] = 54
+		needle := []int{6, 5, 4}
+
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				findFirstOccurance(plainHaystack{intElements(haystack), notation{base: 10}}, digitStrings(needle))
+			}
+		})
+	}
+}
+
+func TestSearchNeedleModes(t *testing.T) {
+	haystack := []int{7, 1543, 65, 10070, 654}
+
+	for _, s := range []struct {
+		name          string
+		opts          []Option
+		needle        []int
+		strings       []string
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:     "substring_int_needle",
+			needle:   []int{54, 7},
+			expected: []int{1, 3},
+		},
+		{
+			name:     "substring_string_needle",
+			strings:  []string{"65", "007"},
+			expected: []int{2, 3},
+		},
+		{
+			name:     "leading_zeros_are_kept",
+			strings:  []string{"007"},
+			expected: []int{3},
+		},
+		{
+			name:     "single_digit_int_needle",
+			opts:     []Option{WithSingleDigitNeedle()},
+			needle:   []int{4, 0},
+			expected: []int{1, 3},
+		},
+		{
+			name:     "single_digit_string_needle",
+			opts:     []Option{WithSingleDigitNeedle(), WithIndex()},
+			strings:  []string{"6", "4"},
+			expected: []int{2, 4},
+		},
+		{
+			name:          "single_digit_int_needle_error",
+			opts:          []Option{WithSingleDigitNeedle()},
+			needle:        []int{5, 54},
+			expectedError: ErrNeedleNotDigit,
+		},
+		{
+			name:          "single_digit_negative_needle_error",
+			opts:          []Option{WithSingleDigitNeedle()},
+			needle:        []int{-5},
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "single_digit_string_needle_error",
+			opts:          []Option{WithSingleDigitNeedle()},
+			strings:       []string{"65"},
+			expectedError: ErrNeedleNotDigit,
+		},
+		{
+			name:          "not_digits_error",
+			strings:       []string{"6a"},
+			expectedError: ErrNeedleNotDigits,
+		},
+		{
+			name:          "empty_element_error",
+			strings:       []string{""},
+			expectedError: ErrNeedleNotDigits,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			search := mustSearch(t, haystack, s.opts...)
+			for _, find := range []func() ([]int, error){
+				func() ([]int, error) {
+					if s.strings != nil {
+						return search.FirstStrings(s.strings)
+					}
+					return search.First(s.needle)
+				},
+				func() ([]int, error) {
+					if s.strings != nil {
+						return search.FirstStringsWithMaxDistance(s.strings, 3)
+					}
+					return search.FirstWithMaxDistance(s.needle, 3)
+				},
+				func() ([]int, error) {
+					if s.strings != nil {
+						return search.FirstStringsWithMinDistance(s.strings)
+					}
+					return search.FirstWithMinDistance(s.needle)
+				},
+			} {
+				actual, err := find()
+				assert.Equal(t, s.expected, actual)
+				assert.ErrorIs(t, err, s.expectedError)
+			}
+		})
+	}
+}
+
+func TestSearchBase(t *testing.T) {
+	for _, s := range []struct {
+		name          string
+		haystack      []int
+		opts          []Option
+		needle        []int
+		strings       []string
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:     "hexadecimal",
+			haystack: []int{0x1A3, 0x2F0, 0xFA},
+			opts:     []Option{WithBase(16)},
+			needle:   []int{0xA, 0xF},
+			expected: []int{0, 1},
+		},
+		{
+			name:     "hexadecimal_indexed",
+			haystack: []int{0x1A3, 0x2F0, 0xFA},
+			opts:     []Option{WithBase(16), WithIndex()},
+			needle:   []int{0xA, 0xF},
+			expected: []int{0, 1},
+		},
+		{
+			name:     "hexadecimal_substring",
+			haystack: []int{0x1A3, 0x2AF0},
+			opts:     []Option{WithBase(16), WithIndex()},
+			needle:   []int{0xAF},
+			expected: []int{1},
+		},
+		{
+			name:     "hexadecimal_uppercase_strings",
+			haystack: []int{0x1A3, 0x2AF0},
+			opts:     []Option{WithBase(16)},
+			strings:  []string{"A", "aF"},
+			expected: []int{0, 1},
+		},
+		{
+			name:     "octal",
+			haystack: []int{0755, 0644, 0600},
+			opts:     []Option{WithBase(8), WithSingleDigitNeedle()},
+			needle:   []int{7, 4},
+			expected: []int{0, 1},
+		},
+		{
+			name:          "octal_needle_digit_out_of_base",
+			haystack:      []int{0755},
+			opts:          []Option{WithBase(8), WithSingleDigitNeedle()},
+			needle:        []int{8},
+			expectedError: ErrNeedleNotDigit,
+		},
+		{
+			name:          "octal_needle_string_out_of_base",
+			haystack:      []int{0755},
+			opts:          []Option{WithBase(8)},
+			strings:       []string{"78"},
+			expectedError: ErrNeedleNotDigits,
+		},
+		{
+			name:          "base_too_small",
+			haystack:      []int{1},
+			opts:          []Option{WithBase(1)},
+			needle:        []int{1},
+			expectedError: ErrInvalidBase,
+		},
+		{
+			name:          "base_too_large",
+			haystack:      []int{1},
+			opts:          []Option{WithBase(37)},
+			strings:       []string{"1"},
+			expectedError: ErrInvalidBase,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			search, err := NewSearch(s.haystack, s.opts...)
+			if err != nil {
+				assert.ErrorIs(t, err, s.expectedError)
+				return
+			}
+
+			var actual []int
+			if s.strings != nil {
+				actual, err = search.FirstStrings(s.strings)
+			} else {
+				actual, err = search.First(s.needle)
+			}
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestSearchBaseElements(t *testing.T) {
+	bigSearch, err := NewBigSearch([]*big.Int{big.NewInt(0xABC), big.NewInt(0xDEF)}, WithBase(16), WithIndex())
+	require.NoError(t, err)
+	actual, err := bigSearch.First([]int{0xB, 0xE})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, actual)
+
+	stringSearch, err := NewStringSearch([]string{"0011", "-1010"}, WithBase(2))
+	require.NoError(t, err)
+	actual, err = stringSearch.FirstStrings([]string{"00", "101"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, actual)
+
+	_, err = NewStringSearch([]string{"0012"}, WithBase(2))
+	assert.ErrorIs(t, err, ErrInvalidElement)
+
+	_, err = NewBigSearch(nil, WithBase(0))
+	assert.ErrorIs(t, err, ErrInvalidBase)
+}
+
+func TestIndexedSearchBase(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 300; n++ {
+		base := 2 + rnd.Intn(35)
+		haystack := make([]int, 1+rnd.Intn(30))
+		for i := range haystack {
+			haystack[i] = rnd.Intn(5000)
+		}
+		needle := make([]int, 1+rnd.Intn(len(haystack)))
+		for i := range needle {
+			needle[i] = rnd.Intn(base + 2)
+		}
+
+		expected, expectedErr := mustSearch(t, haystack, WithBase(base)).FirstWithMinDistance(needle)
+		actual, actualErr := mustSearch(t, haystack, WithBase(base), WithIndex()).FirstWithMinDistance(needle)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, expectedErr, actualErr)
+	}
+}
+
+// mustSearch returns the Search for options known to be valid.
+func mustSearch(t testing.TB, haystack []int, opts ...Option) *Search {
+	t.Helper()
+	s, err := NewSearch(haystack, opts...)
+	require.NoError(t, err)
+	return s
+}
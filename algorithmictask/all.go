@@ -0,0 +1,56 @@
+package algorithmictask
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidPage = errors.New("offset must not be negative and limit must be positive")
+
+// Seq yields values until yield returns false, like iter.Seq, so an iter.Seq[int] converts to it.
+// All yields the matches of a needle as Seq[[]int], and FirstFromSeq reads the haystack
+// elements from a Seq[int].
+type Seq[V any] func(yield func(V) bool)
+
+// All is a shorthand for NewSearch(haystack) followed by All(needle).
+func All(haystack, needle []int) (Seq[[]int], error) {
+	// there is no option to fail
+	s, _ := NewSearch(haystack)
+	return s.All(needle)
+}
+
+// All yields the lowest match for each start index in ascending order, computing them only as
+// they are consumed, so breaking out early costs nothing for the rest of the haystack.
+func (s *Search) All(needle []int) (Seq[[]int], error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findAllOccurances(s.matcher(), elements)
+}
+
+// Page returns at most limit matches of All after skipping the first offset ones.
+func (s *Search) Page(needle []int, offset, limit int) ([][]int, error) {
+	if offset < 0 || limit <= 0 {
+		return nil, wrapErr(ErrInvalidPage, fmt.Sprintf("offset %d, limit %d", offset, limit))
+	}
+
+	occurances, err := s.All(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	page := [][]int{}
+	occurances(func(occurance []int) bool {
+		if offset > 0 {
+			offset--
+			return true
+		}
+
+		page = append(page, occurance)
+		return len(page) < limit
+	})
+
+	return page, nil
+}
@@ -0,0 +1,113 @@
+package algorithmictask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	occurances, err := All(haystack, []int{6, 5, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{0, 1, 4}, {1, 5, 8}, {4, 5, 8}, {5, 8, 10}, {7, 8, 10}, {8, 9, 10}}, collect(occurances))
+
+	var consumed [][]int
+	occurances(func(occurance []int) bool {
+		consumed = append(consumed, occurance)
+		return len(consumed) < 2
+	})
+	assert.Equal(t, [][]int{{0, 1, 4}, {1, 5, 8}}, consumed)
+
+	_, err = All(nil, []int{6})
+	assert.ErrorIs(t, err, ErrHaystackEmpty)
+
+	_, err = mustSearch(t, haystack, WithSingleDigitNeedle()).All([]int{65})
+	assert.ErrorIs(t, err, ErrNeedleNotDigit)
+}
+
+func TestPage(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name          string
+		needle        []int
+		offset, limit int
+		mockedReturn  func(matcher, []string) (Seq[[]int], error)
+		expected      [][]int
+		expectedError error
+	}{
+		{
+			name:     "first_page",
+			needle:   []int{6, 5, 4},
+			offset:   0,
+			limit:    2,
+			expected: [][]int{{0, 1, 4}, {1, 5, 8}},
+		},
+		{
+			name:     "middle_page",
+			needle:   []int{6, 5, 4},
+			offset:   2,
+			limit:    2,
+			expected: [][]int{{4, 5, 8}, {5, 8, 10}},
+		},
+		{
+			name:     "last_page",
+			needle:   []int{6, 5, 4},
+			offset:   4,
+			limit:    5,
+			expected: [][]int{{7, 8, 10}, {8, 9, 10}},
+		},
+		{
+			name:     "after_the_last_page",
+			needle:   []int{6, 5, 4},
+			offset:   6,
+			limit:    5,
+			expected: [][]int{},
+		},
+		{
+			name:   "stops_after_the_limit",
+			needle: []int{6},
+			offset: 1,
+			limit:  1,
+			mockedReturn: func(matcher, []string) (Seq[[]int], error) {
+				return fakeOccurances([]int{0}, []int{1}, nil), nil
+			},
+			expected: [][]int{{1}},
+		},
+		{
+			name:          "negative_offset",
+			needle:        []int{6},
+			offset:        -1,
+			limit:         1,
+			expectedError: ErrInvalidPage,
+		},
+		{
+			name:          "zero_limit",
+			needle:        []int{6},
+			expectedError: ErrInvalidPage,
+		},
+		{
+			name:          "receiving_error",
+			needle:        []int{6},
+			limit:         1,
+			mockedReturn:  func(matcher, []string) (Seq[[]int], error) { return nil, errFindAllOccurances },
+			expectedError: errFindAllOccurances,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			if s.mockedReturn != nil {
+				bkp := findAllOccurances
+				defer func() {
+					findAllOccurances = bkp
+				}()
+				findAllOccurances = s.mockedReturn
+			}
+
+			actual, err := mustSearch(t, haystack).Page(s.needle, s.offset, s.limit)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
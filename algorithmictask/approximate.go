@@ -0,0 +1,87 @@
+package algorithmictask
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidSkips = errors.New("maxSkips must be between 0 and len(needle)-1")
+
+// Skipped marks the needle elements left out of an approximate match.
+const Skipped = -1
+
+// FirstApproximate is like First, but up to maxSkips needle elements may be left out, which are
+// marked with Skipped in the result. It returns the match with the fewest skips, and from those
+// the one with the lowest indexes, skipping later needle elements on a tie. Without skips it
+// returns the same match as First.
+func (s *Search) FirstApproximate(needle []int, maxSkips int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstApproximateOccurance(s.matcher(), elements, maxSkips)
+}
+
+func findFirstApproximateOccurance(haystack matcher, needle []string, maxSkips int) ([]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	if maxSkips < 0 || maxSkips >= len(needle) {
+		return nil, wrapErr(ErrInvalidSkips, fmt.Sprintf("%d for %d elements", maxSkips, len(needle)))
+	}
+
+	// latest[p][r] is the highest index from which needle[p:] can still be matched with at most r
+	// skips, or -1 if it can not be matched at all.
+	n, m := haystack.len(), len(needle)
+	latest := make([][]int, m+1)
+	latest[m] = make([]int, maxSkips+1)
+	for r := range latest[m] {
+		latest[m][r] = n
+	}
+	for p := m - 1; p >= 0; p-- {
+		latest[p] = make([]int, maxSkips+1)
+		for r := range latest[p] {
+			latest[p][r] = prevMatch(haystack, latest[p+1][r], needle[p])
+			if r > 0 {
+				latest[p][r] = max(latest[p][r], latest[p+1][r-1])
+			}
+		}
+	}
+
+	skips := 0
+	for skips <= maxSkips && latest[0][skips] < 0 {
+		skips++
+	}
+	if skips > maxSkips {
+		return []int{}, nil
+	}
+
+	result := make([]int, m)
+	for k := range result {
+		result[k] = Skipped
+	}
+
+	// Each step matches the next needle element at the lowest index that still leaves a match for
+	// the rest, trying the elements reachable with the skips left.
+	for p, cursor := 0, 0; p < m; {
+		q, i := -1, n
+		for candidate := p; candidate < m && candidate-p <= skips; candidate++ {
+			j := haystack.next(cursor, needle[candidate])
+			if j < i && j < latest[candidate+1][skips-(candidate-p)] {
+				q, i = candidate, j
+			}
+		}
+
+		if q < 0 {
+			break
+		}
+
+		result[q] = i
+		skips -= q - p
+		p, cursor = q+1, i+1
+	}
+
+	return result, nil
+}
@@ -0,0 +1,142 @@
+package algorithmictask
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"solvencyanalytics/algorithmictask/subsequence"
+)
+
+func TestFirstApproximate(t *testing.T) {
+	for _, s := range []struct {
+		name          string
+		haystack      []int
+		needle        []int
+		maxSkips      int
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:     "exact",
+			haystack: []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664},
+			needle:   []int{6, 5, 4},
+			maxSkips: 1,
+			expected: []int{0, 1, 4},
+		},
+		{
+			name:     "skip_first",
+			haystack: []int{1, 2, 9},
+			needle:   []int{9, 1, 2},
+			maxSkips: 1,
+			expected: []int{Skipped, 0, 1},
+		},
+		{
+			name:     "skip_missing",
+			haystack: []int{61, 5, 7, 44, 8},
+			needle:   []int{6, 3, 5, 3, 4},
+			maxSkips: 3,
+			expected: []int{0, Skipped, 1, Skipped, 3},
+		},
+		{
+			name:     "skip_later_on_tie",
+			haystack: []int{5, 7},
+			needle:   []int{5, 5},
+			maxSkips: 1,
+			expected: []int{0, Skipped},
+		},
+		{
+			name:     "too_many_skips_needed",
+			haystack: []int{1, 2, 9},
+			needle:   []int{9, 1, 2},
+			expected: []int{},
+		},
+		{
+			name:          "negative_skips",
+			haystack:      []int{1, 2, 9},
+			needle:        []int{9, 1, 2},
+			maxSkips:      -1,
+			expectedError: ErrInvalidSkips,
+		},
+		{
+			name:          "skipping_all",
+			haystack:      []int{1, 2, 9},
+			needle:        []int{9, 1, 2},
+			maxSkips:      3,
+			expectedError: ErrInvalidSkips,
+		},
+		{
+			name:          "needle_error",
+			haystack:      []int{1},
+			needle:        []int{-1},
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "validation_error",
+			haystack:      []int{1},
+			expectedError: ErrNeedleEmpty,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := mustSearch(t, s.haystack).FirstApproximate(s.needle, s.maxSkips)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestFirstApproximateOracle(t *testing.T) {
+	for _, c := range (randomCases{n: 1000, maxLen: 12, maxNeedle: 12}).generate() {
+		haystack, needle := c.haystack, c.needle
+		maxSkips := c.rnd.Intn(len(needle))
+
+		// Every set of skipped elements is tried with the lowest indexes for the rest, preferring
+		// fewer skips, then lower indexes, then a needle element matched over a skipped one.
+		less := func(a, b []int) bool {
+			matchedA, matchedB := slices.DeleteFunc(slices.Clone(a), isSkipped), slices.DeleteFunc(slices.Clone(b), isSkipped)
+			if len(matchedA) != len(matchedB) {
+				return len(matchedA) > len(matchedB)
+			}
+			if c := slices.Compare(matchedA, matchedB); c != 0 {
+				return c < 0
+			}
+			k := 0
+			for a[k] == b[k] {
+				k++
+			}
+			return b[k] == Skipped
+		}
+		expected := []int{}
+		for set := 0; set < 1<<len(needle); set++ {
+			result, start, skips := make([]int, len(needle)), 0, 0
+			for k := range needle {
+				result[k] = Skipped
+				if set&(1<<k) != 0 {
+					skips++
+					continue
+				}
+				for start < len(haystack) && !subsequence.Digits(haystack[start], strconv.Itoa(needle[k])) {
+					start++
+				}
+				if start == len(haystack) {
+					skips = len(needle)
+					break
+				}
+				result[k] = start
+				start++
+			}
+			if skips <= maxSkips && (len(expected) == 0 || less(result, expected)) {
+				expected = result
+			}
+		}
+
+		actual, err := mustSearch(t, haystack, WithIndex()).FirstApproximate(needle, maxSkips)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v, maxSkips: %d", haystack, needle, maxSkips)
+	}
+}
+
+func isSkipped(i int) bool {
+	return i == Skipped
+}
@@ -0,0 +1,84 @@
+package algorithmictask
+
+// BatchQuery is one needle of a batch. A zero MaxDistance asks for the first occurrence, anything
+// else for the first occurrence within MaxDistance.
+type BatchQuery struct {
+	Needle      []int
+	MaxDistance int
+}
+
+type BatchResult struct {
+	Result []int
+	Err    error
+}
+
+// Batch answers the queries in order. Without an index the first occurrences are found in a
+// single shared pass over the haystack, and an index is built once for the max distance
+// queries. A failing query only sets the Err of its own result.
+func (s *Search) Batch(queries []BatchQuery) []BatchResult {
+	results := make([]BatchResult, len(queries))
+
+	var firsts, windows []int
+	needles := make([][]string, len(queries))
+	for i, query := range queries {
+		needles[i], results[i].Err = s.needleStrings(query.Needle)
+		if results[i].Err == nil && query.MaxDistance == 0 {
+			results[i].Err = validate(s.matcher(), needles[i])
+		}
+
+		switch {
+		case results[i].Err != nil:
+		case query.MaxDistance == 0:
+			firsts = append(firsts, i)
+		default:
+			windows = append(windows, i)
+		}
+	}
+
+	haystack := s.matcher()
+	if s.index == nil && len(windows) > 0 {
+		haystack = newHaystackIndex(s.haystack, s.notation)
+	}
+
+	if _, ok := haystack.(*HaystackIndex); !ok {
+		s.findFirstOccurancesInOnePass(needles, firsts, results)
+	} else {
+		for _, i := range firsts {
+			results[i].Result = findFrom(haystack, needles[i], 0)
+		}
+	}
+
+	for _, i := range windows {
+		results[i].Result, results[i].Err = findFirstOccuranceWithMaxDistanceLimit(haystack, needles[i], queries[i].MaxDistance)
+	}
+
+	return results
+}
+
+// findFirstOccurancesInOnePass formats each haystack element once and advances every pending
+// needle on it, until all of them are found or the haystack ends.
+func (s *Search) findFirstOccurancesInOnePass(needles [][]string, pending []int, results []BatchResult) {
+	for _, i := range pending {
+		results[i].Result = make([]int, 0, len(needles[i]))
+	}
+
+	for j := 0; j < s.haystack.len() && len(pending) > 0; j++ {
+		number := s.haystack.format(j, s.notation.base)
+		active := pending[:0]
+		for _, i := range pending {
+			result := results[i].Result
+			if s.notation.contains(number, needles[i][len(result)]) {
+				results[i].Result = append(result, j)
+			}
+
+			if len(results[i].Result) < len(needles[i]) {
+				active = append(active, i)
+			}
+		}
+		pending = active
+	}
+
+	for _, i := range pending {
+		results[i].Result = []int{}
+	}
+}
@@ -0,0 +1,141 @@
+package algorithmictask
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatch(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name     string
+		opts     []Option
+		queries  []BatchQuery
+		expected []BatchResult
+	}{
+		{
+			name: "mixed_queries",
+			opts: []Option{WithSingleDigitNeedle()},
+			queries: []BatchQuery{
+				{Needle: []int{6, 5, 4}},
+				{Needle: []int{6, 5, 4}, MaxDistance: 3},
+				{Needle: nil},
+				{Needle: []int{54}},
+				{Needle: []int{6, 5, 4}, MaxDistance: -1},
+				{Needle: []int{6, 5, 4}, MaxDistance: 1},
+				{Needle: []int{3, 3}},
+			},
+			expected: []BatchResult{
+				{Result: []int{0, 1, 4}},
+				{Result: []int{7, 8, 10}},
+				{Err: ErrNeedleEmpty},
+				{Err: ErrNeedleNotDigit},
+				{Err: ErrDistanceMustBePositive},
+				{Result: []int{}},
+				{Result: []int{1, 2}},
+			},
+		},
+		{
+			name: "first_occurances_in_one_pass",
+			queries: []BatchQuery{
+				{Needle: []int{6, 5, 4}},
+				{Needle: []int{54, 54}},
+				{Needle: []int{9, 9, 9}},
+				{Needle: []int{7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7}},
+				{Needle: []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}},
+			},
+			expected: []BatchResult{
+				{Result: []int{0, 1, 4}},
+				{Result: []int{1, 5}},
+				{Result: []int{4, 5, 8}},
+				{Err: ErrHaystackShorter},
+				{Result: []int{}},
+			},
+		},
+		{
+			name: "indexed_search",
+			opts: []Option{WithIndex()},
+			queries: []BatchQuery{
+				{Needle: []int{54}},
+				{Needle: []int{6, 5, 4}, MaxDistance: 12},
+			},
+			expected: []BatchResult{
+				{Result: []int{1}},
+				{Err: ErrDistanceTooLarge},
+			},
+		},
+		{
+			name:     "no_queries",
+			expected: []BatchResult{},
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual := mustSearch(t, haystack, s.opts...).Batch(s.queries)
+			assert.Len(t, actual, len(s.expected))
+			for i, expected := range s.expected {
+				assert.Equal(t, expected.Result, actual[i].Result, i)
+				assert.ErrorIs(t, actual[i].Err, expected.Err, i)
+			}
+		})
+	}
+}
+
+func TestBatchMatchesSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	haystack := make([]int, 200)
+	for i := range haystack {
+		haystack[i] = rnd.Intn(100_000)
+	}
+	search := mustSearch(t, haystack)
+
+	queries := make([]BatchQuery, 300)
+	for i := range queries {
+		queries[i].Needle = make([]int, rnd.Intn(8))
+		for j := range queries[i].Needle {
+			queries[i].Needle[j] = rnd.Intn(10)
+		}
+		queries[i].MaxDistance = rnd.Intn(30)
+	}
+
+	for i, actual := range search.Batch(queries) {
+		var expected []int
+		var expectedErr error
+		if queries[i].MaxDistance == 0 {
+			expected, expectedErr = search.First(queries[i].Needle)
+		} else {
+			expected, expectedErr = search.FirstWithMaxDistance(queries[i].Needle, queries[i].MaxDistance)
+		}
+		assert.Equal(t, expected, actual.Result)
+		assert.Equal(t, expectedErr, actual.Err)
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	haystack := make([]int, 100_000)
+	for i := range haystack {
+		haystack[i] = rnd.Intn(1_000_000)
+	}
+	queries := make([]BatchQuery, 100)
+	for i := range queries {
+		queries[i].Needle = []int{rnd.Intn(10), rnd.Intn(10), rnd.Intn(10), rnd.Intn(10)}
+	}
+	search := mustSearch(b, haystack)
+
+	b.Run("one_by_one", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, query := range queries {
+				search.First(query.Needle)
+			}
+		}
+	})
+
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			search.Batch(queries)
+		}
+	})
+}
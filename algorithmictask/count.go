@@ -0,0 +1,73 @@
+package algorithmictask
+
+import "math/big"
+
+// Count returns the number of distinct index sequences matching the needle, not only the lowest
+// one. It is computed in O(len(haystack)*len(needle)) big.Int additions.
+func (s *Search) Count(needle []int) (*big.Int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return countOccurances(s.matcher(), elements)
+}
+
+// CountWithMaxDistance is like Count, but only counts the index sequences where the distance
+// between the first and the last index does not exceed maxDistance. It takes
+// O(starts*maxDistance*len(needle)) additions, where starts is the number of elements containing
+// the first needle element.
+func (s *Search) CountWithMaxDistance(needle []int, maxDistance int) (*big.Int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return countOccurancesWithMaxDistanceLimit(s.matcher(), elements, maxDistance)
+}
+
+func countOccurances(haystack matcher, needle []string) (*big.Int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	return countFrom(haystack, needle, 0, haystack.len()), nil
+}
+
+func countOccurancesWithMaxDistanceLimit(haystack matcher, needle []string, maxDistance int) (*big.Int, error) {
+	if err := validateDistance(haystack, maxDistance); err != nil {
+		return nil, err
+	}
+
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	count := new(big.Int)
+	for start := haystack.next(0, needle[0]); start < haystack.len(); start = haystack.next(start+1, needle[0]) {
+		end := min(start+maxDistance+1, haystack.len())
+		count.Add(count, countFrom(haystack, needle[1:], start+1, end))
+	}
+
+	return count, nil
+}
+
+// countFrom counts the matches of the needle within haystack[start:end].
+func countFrom(haystack matcher, needle []string, start, end int) *big.Int {
+	// counts[k] is the number of matches of needle[:k] seen so far.
+	counts := make([]*big.Int, len(needle)+1)
+	counts[0] = big.NewInt(1)
+	for k := 1; k < len(counts); k++ {
+		counts[k] = new(big.Int)
+	}
+
+	for i := start; i < end; i++ {
+		for k := len(needle) - 1; k >= 0; k-- {
+			if counts[k].Sign() != 0 && haystack.contains(i, needle[k]) {
+				counts[k+1].Add(counts[k+1], counts[k])
+			}
+		}
+	}
+
+	return counts[len(needle)]
+}
@@ -0,0 +1,151 @@
+package algorithmictask
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"solvencyanalytics/algorithmictask/subsequence"
+)
+
+func TestCount(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name          string
+		haystack      []int
+		needle        []int
+		maxDistance   int
+		expected      int64
+		expectedError error
+	}{
+		{
+			name:     "test_1",
+			haystack: haystack,
+			needle:   []int{6, 5, 4},
+			expected: 21,
+		},
+		{
+			name:        "test_1_with_max_distance",
+			haystack:    haystack,
+			needle:      []int{6, 5, 4},
+			maxDistance: 3,
+			expected:    3,
+		},
+		{
+			name:     "no_results",
+			haystack: []int{1},
+			needle:   []int{2},
+			expected: 0,
+		},
+		{
+			name:        "single_element_needle_with_max_distance",
+			haystack:    []int{1, 2, 1},
+			needle:      []int{1},
+			maxDistance: 1,
+			expected:    2,
+		},
+		{
+			name:          "validation_error",
+			needle:        []int{1},
+			expectedError: ErrHaystackEmpty,
+		},
+		{
+			name:          "validation_error_with_max_distance",
+			needle:        []int{1},
+			maxDistance:   1,
+			expectedError: ErrDistanceTooLarge,
+		},
+		{
+			name:          "distance_positive_error",
+			haystack:      []int{1},
+			needle:        []int{1},
+			maxDistance:   -1,
+			expectedError: ErrDistanceMustBePositive,
+		},
+		{
+			name:          "needle_error",
+			haystack:      []int{1},
+			needle:        []int{-1},
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "needle_error_with_max_distance",
+			haystack:      []int{1},
+			needle:        []int{-1},
+			maxDistance:   1,
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "haystack_is_shorter_with_max_distance",
+			haystack:      []int{1},
+			needle:        []int{1, 1},
+			maxDistance:   1,
+			expectedError: ErrHaystackShorter,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			var actual *big.Int
+			var err error
+			if s.maxDistance == 0 {
+				actual, err = mustSearch(t, s.haystack).Count(s.needle)
+			} else {
+				actual, err = mustSearch(t, s.haystack).CountWithMaxDistance(s.needle, s.maxDistance)
+			}
+
+			assert.ErrorIs(t, err, s.expectedError)
+			if s.expectedError == nil {
+				assert.Equal(t, big.NewInt(s.expected).String(), actual.String())
+			}
+		})
+	}
+}
+
+func TestCountExplodes(t *testing.T) {
+	haystack := make([]int, 200)
+	for i := range haystack {
+		haystack[i] = 1
+	}
+	needle := make([]int, 100)
+	for i := range needle {
+		needle[i] = 1
+	}
+
+	actual, err := mustSearch(t, haystack, WithIndex()).Count(needle)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Binomial(200, 100).String(), actual.String())
+}
+
+func TestCountOracle(t *testing.T) {
+	for _, c := range (randomCases{n: 300, maxLen: 12, maxNeedle: 12}).generate() {
+		haystack, needle, maxDistance := c.haystack, c.needle, c.maxDistance
+		search := mustSearch(t, haystack)
+
+		var expected, expectedWithMaxDistance int64
+		var walk func(result []int, from int)
+		walk = func(result []int, from int) {
+			if len(result) == len(needle) {
+				expected++
+				if result[len(result)-1]-result[0] <= maxDistance {
+					expectedWithMaxDistance++
+				}
+				return
+			}
+			for i := from; i < len(haystack); i++ {
+				if subsequence.Digits(haystack[i], strconv.Itoa(needle[len(result)])) {
+					walk(append(result, i), i+1)
+				}
+			}
+		}
+		walk(nil, 0)
+
+		actual, err := search.Count(needle)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual.Int64())
+
+		actual, err = search.CountWithMaxDistance(needle, maxDistance)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedWithMaxDistance, actual.Int64(), "haystack: %v, needle: %v, maxDistance: %d", haystack, needle, maxDistance)
+	}
+}
@@ -0,0 +1,104 @@
+package algorithmictask
+
+import "fmt"
+
+// Diagnosis explains why a needle could not be matched.
+type Diagnosis struct {
+	// Matched holds the indexes of the longest needle prefix that could be matched.
+	Matched []int
+	// Failed is the position of the first needle element that could not be matched after it,
+	// and Element is that needle element as it was searched for.
+	Failed  int
+	Element string
+	// From and To are the first and last index scanned for the failed element.
+	From, To int
+	// end is the last index of the haystack.
+	end int
+}
+
+func (d *Diagnosis) String() string {
+	switch {
+	case d.To < d.end:
+		return fmt.Sprintf("needle[%d] (%s) does not appear after index %d up to index %d", d.Failed, d.Element, d.From-1, d.To)
+	case d.Failed == 0:
+		return fmt.Sprintf("needle[0] (%s) never appears", d.Element)
+	}
+
+	return fmt.Sprintf("needle[%d] (%s) never appears after index %d", d.Failed, d.Element, d.From-1)
+}
+
+// Diagnose returns nil if First finds the needle, and explains the failure otherwise.
+// FirstWithMinDistance fails for the same needles as First, so it is explained the same way.
+func (s *Search) Diagnose(needle []int) (*Diagnosis, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return diagnose(s.matcher(), elements)
+}
+
+// DiagnoseWithMaxDistance returns nil if FirstWithMaxDistance finds the needle, and explains
+// the failure with the longest prefix matched within maxDistance of its start otherwise.
+func (s *Search) DiagnoseWithMaxDistance(needle []int, maxDistance int) (*Diagnosis, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return diagnoseWithMaxDistanceLimit(s.matcher(), elements, maxDistance)
+}
+
+func diagnose(haystack matcher, needle []string) (*Diagnosis, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	return partialFrom(haystack, needle, 0, haystack.len()-1), nil
+}
+
+func diagnoseWithMaxDistanceLimit(haystack matcher, needle []string, maxDistance int) (*Diagnosis, error) {
+	if err := validateDistance(haystack, maxDistance); err != nil {
+		return nil, err
+	}
+
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	var longest *Diagnosis
+	for start := haystack.next(0, needle[0]); start < haystack.len(); start = haystack.next(start+1, needle[0]) {
+		d := partialFrom(haystack, needle, start, min(start+maxDistance, haystack.len()-1))
+		if d == nil {
+			return nil, nil
+		}
+
+		if longest == nil || len(d.Matched) > len(longest.Matched) {
+			longest = d
+		}
+	}
+
+	if longest == nil {
+		return partialFrom(haystack, needle, 0, haystack.len()-1), nil
+	}
+
+	return longest, nil
+}
+
+// partialFrom matches the needle greedily within the indexes from start to end, and returns nil
+// if all of it could be matched.
+func partialFrom(haystack matcher, needle []string, start, end int) *Diagnosis {
+	d := &Diagnosis{Matched: []int{}, From: start, To: end, end: haystack.len() - 1}
+	for k, element := range needle {
+		i := haystack.next(d.From, element)
+		if i > end {
+			d.Failed, d.Element = k, element
+			return d
+		}
+
+		d.Matched = append(d.Matched, i)
+		d.From = i + 1
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package algorithmictask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnose(t *testing.T) {
+	for _, s := range []struct {
+		name          string
+		haystack      []int
+		opts          []Option
+		needle        []int
+		maxDistance   int
+		expected      *Diagnosis
+		message       string
+		expectedError error
+	}{
+		{
+			name:     "missing_suffix",
+			haystack: []int{6, 5, 7, 4, 1},
+			needle:   []int{6, 5, 4, 9},
+			expected: &Diagnosis{Matched: []int{0, 1, 3}, Failed: 3, Element: "9", From: 4, To: 4, end: 4},
+			message:  "needle[3] (9) never appears after index 3",
+		},
+		{
+			name:     "missing_first_element",
+			haystack: []int{6, 5, 7, 4, 1},
+			needle:   []int{8},
+			expected: &Diagnosis{Matched: []int{}, Failed: 0, Element: "8", From: 0, To: 4, end: 4},
+			message:  "needle[0] (8) never appears",
+		},
+		{
+			name:     "base",
+			haystack: []int{6, 5, 7, 4, 1},
+			opts:     []Option{WithBase(16)},
+			needle:   []int{5, 10},
+			expected: &Diagnosis{Matched: []int{1}, Failed: 1, Element: "a", From: 2, To: 4, end: 4},
+			message:  "needle[1] (a) never appears after index 1",
+		},
+		{
+			name:     "match",
+			haystack: []int{6, 5, 7, 4, 1},
+			needle:   []int{6, 5, 4},
+		},
+		{
+			name:        "max_distance",
+			haystack:    []int{6, 5, 7, 4, 1, 6, 5, 2, 4},
+			needle:      []int{6, 5, 4},
+			maxDistance: 2,
+			expected:    &Diagnosis{Matched: []int{0, 1}, Failed: 2, Element: "4", From: 2, To: 2, end: 8},
+			message:     "needle[2] (4) does not appear after index 1 up to index 2",
+		},
+		{
+			name:        "max_distance_longest_prefix",
+			haystack:    []int{6, 7, 7, 5, 6, 5, 2, 4},
+			needle:      []int{6, 5, 4},
+			maxDistance: 2,
+			expected:    &Diagnosis{Matched: []int{4, 5}, Failed: 2, Element: "4", From: 6, To: 6, end: 7},
+			message:     "needle[2] (4) does not appear after index 5 up to index 6",
+		},
+		{
+			name:        "max_distance_missing_first_element",
+			haystack:    []int{6, 5, 7, 4, 1},
+			needle:      []int{8, 5},
+			maxDistance: 2,
+			expected:    &Diagnosis{Matched: []int{}, Failed: 0, Element: "8", From: 0, To: 4, end: 4},
+			message:     "needle[0] (8) never appears",
+		},
+		{
+			name:        "max_distance_match",
+			haystack:    []int{6, 5, 7, 4, 1, 6, 5, 2, 4},
+			needle:      []int{6, 5, 4},
+			maxDistance: 3,
+		},
+		{
+			name:          "needle_error",
+			haystack:      []int{6},
+			needle:        []int{-6},
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "max_distance_needle_error",
+			haystack:      []int{6},
+			needle:        []int{-6},
+			maxDistance:   1,
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "validation_error",
+			haystack:      []int{6},
+			expectedError: ErrNeedleEmpty,
+		},
+		{
+			name:          "max_distance_validation_error",
+			haystack:      []int{6},
+			maxDistance:   1,
+			expectedError: ErrNeedleEmpty,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			search := mustSearch(t, s.haystack, s.opts...)
+
+			var actual *Diagnosis
+			var err error
+			if s.maxDistance == 0 {
+				actual, err = search.Diagnose(s.needle)
+			} else {
+				actual, err = search.DiagnoseWithMaxDistance(s.needle, s.maxDistance)
+			}
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+			if s.expected != nil {
+				assert.Equal(t, s.message, actual.String())
+			}
+		})
+	}
+
+	_, err := mustSearch(t, []int{6}).DiagnoseWithMaxDistance([]int{6}, 0)
+	assert.ErrorIs(t, err, ErrDistanceMustBePositive)
+}
+
+func TestDiagnoseMatchesSearch(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+	search := mustSearch(t, haystack, WithIndex())
+
+	for _, needle := range [][]int{{6, 5, 4}, {6, 5, 4, 9}, {2, 2}, {8, 9, 0, 6}} {
+		for maxDistance := 0; maxDistance <= 4; maxDistance++ {
+			var expected []int
+			var d *Diagnosis
+			if maxDistance == 0 {
+				expected, _ = search.First(needle)
+				d, _ = search.Diagnose(needle)
+			} else {
+				expected, _ = search.FirstWithMaxDistance(needle, maxDistance)
+				d, _ = search.DiagnoseWithMaxDistance(needle, maxDistance)
+			}
+			assert.Equal(t, len(expected) == 0, d != nil, "needle: %v, maxDistance: %d", needle, maxDistance)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package algorithmictask
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// elements renders the haystack elements, so the search does not depend on how they are stored.
+type elements interface {
+	len() int
+	// format returns the digits of the i-th element in the given base.
+	format(i, base int) string
+	validate(base int) error
+}
+
+type intElements []int
+
+func (e intElements) len() int {
+	return len(e)
+}
+
+func (e intElements) format(i, base int) string {
+	return strconv.FormatInt(int64(e[i]), base)
+}
+
+func (e intElements) validate(int) error {
+	return nil
+}
+
+type bigElements []*big.Int
+
+func (e bigElements) len() int {
+	return len(e)
+}
+
+func (e bigElements) format(i, base int) string {
+	return e[i].Text(base)
+}
+
+func (e bigElements) validate(int) error {
+	for i, number := range e {
+		if number == nil {
+			return wrapErr(ErrInvalidElement, "nil at index "+strconv.Itoa(i))
+		}
+	}
+
+	return nil
+}
+
+// stringElements are kept as they are, so leading zeros count as digits.
+type stringElements []string
+
+func (e stringElements) len() int {
+	return len(e)
+}
+
+func (e stringElements) format(i, _ int) string {
+	return e[i]
+}
+
+func (e stringElements) validate(base int) error {
+	for _, number := range e {
+		if !isDigits(strings.TrimPrefix(number, "-"), base) {
+			return wrapErr(ErrInvalidElement, strconv.Quote(number))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package algorithmictask
+
+import (
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElementSearch(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+	bigHaystack := make([]*big.Int, len(haystack))
+	stringHaystack := make([]string, len(haystack))
+	for i, number := range haystack {
+		bigHaystack[i] = big.NewInt(int64(number))
+		stringHaystack[i] = strconv.Itoa(number)
+	}
+	needle := []int{6, 5, 4}
+
+	bigSearch, err := NewBigSearch(bigHaystack)
+	require.NoError(t, err)
+	indexedBigSearch, err := NewBigSearch(bigHaystack, WithIndex())
+	require.NoError(t, err)
+	stringSearch, err := NewStringSearch(stringHaystack)
+	require.NoError(t, err)
+
+	for _, s := range []struct {
+		name   string
+		search *Search
+	}{
+		{name: "big", search: bigSearch},
+		{name: "indexed_big", search: indexedBigSearch},
+		{name: "string", search: stringSearch},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := s.search.First(needle)
+			assert.NoError(t, err)
+			assert.Equal(t, []int{0, 1, 4}, actual)
+
+			actual, err = s.search.FirstWithMaxDistance(needle, 3)
+			assert.NoError(t, err)
+			assert.Equal(t, []int{7, 8, 10}, actual)
+
+			actual, err = s.search.FirstWithMinDistance(needle)
+			assert.NoError(t, err)
+			assert.Equal(t, []int{8, 9, 10}, actual)
+		})
+	}
+}
+
+func TestElementSearchBeyondInt64(t *testing.T) {
+	identifier, ok := new(big.Int).SetString("-123456789012345678901234567890", 10)
+	require.True(t, ok)
+
+	bigSearch, err := NewBigSearch([]*big.Int{big.NewInt(5), identifier})
+	require.NoError(t, err)
+	actual, err := bigSearch.FirstStrings([]string{"5", "8901234567890"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, actual)
+
+	stringSearch, err := NewStringSearch([]string{"00042", "123456789012345678901234567890", "7"})
+	require.NoError(t, err)
+	actual, err = stringSearch.First([]int{0, 0, 7})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, actual)
+
+	actual, err = stringSearch.FirstStrings([]string{"000", "0"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, actual)
+
+	actual, err = stringSearch.FirstStrings([]string{"0000"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, actual)
+}
+
+func TestElementSearchErrors(t *testing.T) {
+	_, err := NewBigSearch([]*big.Int{big.NewInt(1), nil})
+	assert.ErrorIs(t, err, ErrInvalidElement)
+
+	for _, element := range []string{"", "-", "1a", "+1", " 1"} {
+		_, err = NewStringSearch([]string{"1", element})
+		assert.ErrorIs(t, err, ErrInvalidElement, element)
+	}
+
+	search, err := NewStringSearch([]string{"-12"})
+	require.NoError(t, err)
+	actual, err := search.First([]int{2})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0}, actual)
+
+	_, err = NewStringSearch(nil)
+	assert.NoError(t, err)
+}
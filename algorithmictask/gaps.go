@@ -0,0 +1,118 @@
+package algorithmictask
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidGaps = errors.New("invalid gaps")
+
+// Gap limits the distance between the indexes of two neighbouring needle elements. The distance
+// is always at least 1, and a zero Max means no upper limit.
+type Gap struct {
+	Min, Max int
+}
+
+// FirstWithGaps returns the lowest indexes matching the needle, where each result[k+1]-result[k]
+// is within the gaps. The gaps are either a single Gap for every step, or one Gap for each of
+// the len(needle)-1 steps.
+func (s *Search) FirstWithGaps(needle []int, gaps ...Gap) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceWithGaps(s.matcher(), elements, gaps)
+}
+
+func findFirstOccuranceWithGaps(haystack matcher, needle []string, gaps []Gap) ([]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	steps, err := gapSteps(gaps, len(needle)-1)
+	if err != nil {
+		return nil, err
+	}
+
+	// feasible[k][i] reports whether needle[k:] can be matched with needle[k] at index i, and
+	// next[i] is the lowest feasible index from i for the needle element processed last.
+	n := haystack.len()
+	feasible := make([][]bool, len(needle))
+	next := make([]int, n+1)
+	for k := len(needle) - 1; k >= 0; k-- {
+		feasible[k] = make([]bool, n)
+		for i := 0; i < n; i++ {
+			if !haystack.contains(i, needle[k]) {
+				continue
+			}
+
+			if k == len(needle)-1 {
+				feasible[k][i] = true
+			} else {
+				lo, hi := steps[k].bounds(i, n)
+				feasible[k][i] = lo <= hi && next[lo] <= hi
+			}
+		}
+
+		next[n] = n
+		for i := n - 1; i >= 0; i-- {
+			if feasible[k][i] {
+				next[i] = i
+			} else {
+				next[i] = next[i+1]
+			}
+		}
+	}
+
+	if next[0] == n {
+		return []int{}, nil
+	}
+
+	result := make([]int, len(needle))
+	result[0] = next[0]
+	for k := 1; k < len(needle); k++ {
+		i, _ := steps[k-1].bounds(result[k-1], n)
+		for !feasible[k][i] {
+			i++
+		}
+		result[k] = i
+	}
+
+	return result, nil
+}
+
+// bounds returns the range of indexes allowed after index i, where lo > hi means there is none.
+func (g Gap) bounds(i, n int) (int, int) {
+	// the gaps are compared with the rest of the haystack first, as i+gap could overflow
+	lo, hi := n, n-1
+	if g.Min < n-i {
+		lo = i + max(g.Min, 1)
+	}
+	if g.Max > 0 && g.Max < n-1-i {
+		hi = i + g.Max
+	}
+
+	return lo, hi
+}
+
+func gapSteps(gaps []Gap, steps int) ([]Gap, error) {
+	for _, gap := range gaps {
+		if gap.Min < 0 || gap.Max < 0 || (gap.Max > 0 && gap.Max < gap.Min) {
+			return nil, wrapErr(ErrInvalidGaps, fmt.Sprintf("min %d, max %d", gap.Min, gap.Max))
+		}
+	}
+
+	switch {
+	case len(gaps) == steps:
+		return gaps, nil
+	case len(gaps) == 1:
+		all := make([]Gap, steps)
+		for k := range all {
+			all[k] = gaps[0]
+		}
+		return all, nil
+	}
+
+	return nil, wrapErr(ErrInvalidGaps, fmt.Sprintf("%d gaps for %d steps", len(gaps), steps))
+}
@@ -0,0 +1,148 @@
+package algorithmictask
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"solvencyanalytics/algorithmictask/subsequence"
+)
+
+func TestFirstWithGaps(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name          string
+		needle        []int
+		gaps          []Gap
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:     "without_limits",
+			needle:   []int{6, 5, 4},
+			gaps:     []Gap{{}},
+			expected: []int{0, 1, 4},
+		},
+		{
+			name:     "max_gap_for_all_steps",
+			needle:   []int{6, 5, 4},
+			gaps:     []Gap{{Max: 2}},
+			expected: []int{7, 8, 10},
+		},
+		{
+			name:     "min_gap_for_all_steps",
+			needle:   []int{6, 5, 4},
+			gaps:     []Gap{{Min: 2}},
+			expected: []int{0, 5, 8},
+		},
+		{
+			name:     "gap_for_each_step",
+			needle:   []int{6, 5, 4},
+			gaps:     []Gap{{Min: 2, Max: 3}, {Max: 1}},
+			expected: []int{7, 9, 10},
+		},
+		{
+			name:     "extreme_max_gap",
+			needle:   []int{6, 5, 4},
+			gaps:     []Gap{{Max: math.MaxInt}},
+			expected: []int{0, 1, 4},
+		},
+		{
+			name:     "extreme_min_gap",
+			needle:   []int{6, 5, 4},
+			gaps:     []Gap{{Min: math.MaxInt, Max: math.MaxInt}},
+			expected: []int{},
+		},
+		{
+			name:     "no_results",
+			needle:   []int{6, 5, 4},
+			gaps:     []Gap{{Min: 4, Max: 4}},
+			expected: []int{},
+		},
+		{
+			name:     "single_element_needle",
+			needle:   []int{9},
+			expected: []int{4},
+		},
+		{
+			name:          "too_many_gaps",
+			needle:        []int{6, 5},
+			gaps:          []Gap{{}, {}},
+			expectedError: ErrInvalidGaps,
+		},
+		{
+			name:          "missing_gaps",
+			needle:        []int{6, 5},
+			expectedError: ErrInvalidGaps,
+		},
+		{
+			name:          "negative_gap",
+			needle:        []int{6, 5},
+			gaps:          []Gap{{Min: -1}},
+			expectedError: ErrInvalidGaps,
+		},
+		{
+			name:          "max_below_min",
+			needle:        []int{6, 5},
+			gaps:          []Gap{{Min: 3, Max: 2}},
+			expectedError: ErrInvalidGaps,
+		},
+		{
+			name:          "needle_error",
+			needle:        []int{-6},
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "validation_error",
+			expectedError: ErrNeedleEmpty,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := mustSearch(t, haystack).FirstWithGaps(s.needle, s.gaps...)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestFirstWithGapsOracle(t *testing.T) {
+	for _, c := range (randomCases{n: 1000, maxLen: 14, maxNeedle: 14}).generate() {
+		haystack, needle := c.haystack, c.needle
+		gaps := make([]Gap, len(needle)-1)
+		for k := range gaps {
+			gaps[k].Min = c.rnd.Intn(3)
+			gaps[k].Max = gaps[k].Min + c.rnd.Intn(4)
+			if c.rnd.Intn(4) == 0 {
+				gaps[k].Max = 0
+			}
+		}
+
+		expected := []int{}
+		var walk func(result []int, from int) bool
+		walk = func(result []int, from int) bool {
+			if len(result) == len(needle) {
+				expected = append(expected, result...)
+				return true
+			}
+			for i := from; i < len(haystack); i++ {
+				if k := len(result) - 1; k >= 0 {
+					gap := i - result[k]
+					if gap < gaps[k].Min || (gaps[k].Max > 0 && gap > gaps[k].Max) {
+						continue
+					}
+				}
+				if subsequence.Digits(haystack[i], strconv.Itoa(needle[len(result)])) && walk(append(result, i), i+1) {
+					return true
+				}
+			}
+			return false
+		}
+		walk(nil, 0)
+
+		actual, err := mustSearch(t, haystack, WithIndex()).FirstWithGaps(needle, gaps...)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v, gaps: %v", haystack, needle, gaps)
+	}
+}
@@ -0,0 +1,125 @@
+package algorithmictask
+
+import (
+	"strconv"
+	"strings"
+)
+
+// negativeBit marks the negative elements in the masks, above the digits of any base.
+const negativeBit = 1 << 63
+
+// HaystackIndex stores which digits each haystack element contains and, for each position and
+// digit, the next index containing that digit.
+type HaystackIndex struct {
+	haystack elements
+	notation notation
+	masks    []uint64
+	// nexts holds base entries for each position and one more row for the end of the haystack.
+	nexts []int
+}
+
+func NewHaystackIndex(haystack []int) *HaystackIndex {
+	return newHaystackIndex(intElements(haystack), notation{base: 10})
+}
+
+func newHaystackIndex(haystack elements, notation notation) *HaystackIndex {
+	n, base := haystack.len(), notation.base
+	ix := &HaystackIndex{
+		haystack: haystack,
+		notation: notation,
+		masks:    make([]uint64, n),
+		nexts:    make([]int, (n+1)*base),
+	}
+
+	for digit := 0; digit < base; digit++ {
+		ix.nexts[n*base+digit] = n
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		ix.masks[i] = digitMask(haystack.format(i, base))
+		for digit := 0; digit < base; digit++ {
+			if ix.masks[i]&(1<<digit) != 0 {
+				ix.nexts[i*base+digit] = i
+			} else {
+				ix.nexts[i*base+digit] = ix.nexts[(i+1)*base+digit]
+			}
+		}
+	}
+
+	return ix
+}
+
+func (ix *HaystackIndex) Len() int {
+	return ix.haystack.len()
+}
+
+// Mask returns the digits of the i-th element as a bit set, where bit d is set for digit d and
+// bit 63 is set for negative elements.
+func (ix *HaystackIndex) Mask(i int) uint64 {
+	return ix.masks[i]
+}
+
+// Next returns the lowest index from the given one containing the digit, or Len() if there
+// is none.
+func (ix *HaystackIndex) Next(from, digit int) int {
+	return ix.next(from, strconv.FormatInt(int64(digit), ix.notation.base))
+}
+
+func (ix *HaystackIndex) len() int {
+	return ix.haystack.len()
+}
+
+func (ix *HaystackIndex) contains(i int, element string) bool {
+	digit, negative, ok := ix.digit(element)
+	if !ok {
+		return plainHaystack{ix.haystack, ix.notation}.contains(i, element)
+	}
+
+	return ix.masks[i]&(1<<digit) != 0 && ix.signMatches(i, negative)
+}
+
+func (ix *HaystackIndex) next(from int, element string) int {
+	if from >= ix.haystack.len() {
+		return ix.haystack.len()
+	}
+
+	digit, negative, ok := ix.digit(element)
+	if !ok {
+		return plainHaystack{ix.haystack, ix.notation}.next(from, element)
+	}
+
+	base := ix.notation.base
+	i := ix.nexts[from*base+digit]
+	for i < ix.haystack.len() && !ix.signMatches(i, negative) {
+		i = ix.nexts[(i+1)*base+digit]
+	}
+
+	return i
+}
+
+// digit returns the value of a single digit needle element, and whether it is negative.
+func (ix *HaystackIndex) digit(element string) (int, bool, bool) {
+	digits, negative := strings.CutPrefix(element, "-")
+	if len(digits) != 1 || digitValue(digits[0]) >= ix.notation.base || (negative && ix.notation.sign == SignAbsolute) {
+		return 0, false, false
+	}
+
+	return digitValue(digits[0]), negative, true
+}
+
+func (ix *HaystackIndex) signMatches(i int, negative bool) bool {
+	return ix.notation.sign == SignAbsolute || (ix.masks[i]&negativeBit != 0) == negative
+}
+
+func digitMask(number string) uint64 {
+	var mask uint64
+	for i := 0; i < len(number); i++ {
+		if number[i] == '-' {
+			mask |= negativeBit
+		} else {
+			mask |= 1 << digitValue(number[i])
+		}
+	}
+
+	return mask
+}
@@ -0,0 +1,83 @@
+package algorithmictask
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHaystackIndex(t *testing.T) {
+	ix := NewHaystackIndex([]int{662, -154063, 0, 7})
+
+	assert.Equal(t, 4, ix.Len())
+	assert.Equal(t, uint64(1<<6|1<<2), ix.Mask(0))
+	assert.Equal(t, uint64(negativeBit|1<<1|1<<5|1<<4|1<<0|1<<6|1<<3), ix.Mask(1))
+	assert.Equal(t, uint64(1<<0), ix.Mask(2))
+
+	for _, s := range []struct {
+		name                  string
+		from, digit, expected int
+	}{
+		{name: "same_index", from: 0, digit: 6, expected: 0},
+		{name: "negative_element", from: 1, digit: 6, expected: 1},
+		{name: "next_index", from: 2, digit: 7, expected: 3},
+		{name: "not_found", from: 0, digit: 9, expected: 4},
+		{name: "from_the_end", from: 4, digit: 7, expected: 4},
+		{name: "multi_digit_fallback", from: 0, digit: 55, expected: 4},
+		{name: "multi_digit_fallback_found", from: 0, digit: 15, expected: 1},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, ix.Next(s.from, s.digit))
+		})
+	}
+}
+
+func TestIndexedSearch(t *testing.T) {
+	// Negative haystack elements and two digit needle elements are covered too.
+	for _, c := range (randomCases{n: 500, maxLen: 30, maxNeedle: 30, values: [2]int{-1000, 1000}, digits: [2]int{0, 12}}).generate() {
+		haystack, needle, maxDistance := c.haystack, c.needle, c.maxDistance
+
+		plain, indexed := mustSearch(t, haystack), mustSearch(t, haystack, WithIndex())
+		assert.NotNil(t, indexed.Index())
+
+		expected, expectedErr := plain.First(needle)
+		actual, actualErr := indexed.First(needle)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, expectedErr, actualErr)
+
+		expected, expectedErr = plain.FirstWithMaxDistance(needle, maxDistance)
+		actual, actualErr = indexed.FirstWithMaxDistance(needle, maxDistance)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, expectedErr, actualErr)
+
+		expected, expectedErr = plain.FirstWithMinDistance(needle)
+		actual, actualErr = indexed.FirstWithMinDistance(needle)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, expectedErr, actualErr)
+	}
+}
+
+func BenchmarkIndexedSearch(b *testing.B) {
+	rnd := rand.New(rand.NewSource(1))
+	haystack := make([]int, 100_000)
+	for i := range haystack {
+		haystack[i] = rnd.Intn(1_000_000)
+	}
+	needle := []int{6, 5, 4, 3, 2, 1}
+
+	for _, s := range []struct {
+		name   string
+		search *Search
+	}{
+		{name: "plain", search: mustSearch(b, haystack)},
+		{name: "indexed", search: mustSearch(b, haystack, WithIndex())},
+	} {
+		b.Run(s.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.search.First(needle)
+				s.search.FirstWithMaxDistance(needle, 10)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package algorithmictask
+
+// Last returns the highest indexes of the haystack containing the digits of the needle in order.
+func (s *Search) Last(needle []int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findLastOccurance(s.matcher(), elements)
+}
+
+// LastWithMaxDistance is like Last, but the distance between the first and the last index must
+// not exceed maxDistance.
+func (s *Search) LastWithMaxDistance(needle []int, maxDistance int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findLastOccuranceWithMaxDistanceLimit(s.matcher(), elements, maxDistance)
+}
+
+// LastWithMinDistance is like Last, but the distance between the first and the last index is
+// the smallest possible.
+func (s *Search) LastWithMinDistance(needle []int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findLastOccuranceWithMinimumPossibleDistance(s.matcher(), elements)
+}
+
+func findLastOccurance(haystack matcher, needle []string) ([]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	return findBefore(haystack, needle, haystack.len()), nil
+}
+
+// findLastOccuranceWithMaxDistanceLimit mirrors findFirstOccuranceWithMaxDistanceLimit, taking
+// the highest match for each end index from the right.
+func findLastOccuranceWithMaxDistanceLimit(haystack matcher, needle []string, maxDistance int) ([]int, error) {
+	if err := validateDistance(haystack, maxDistance); err != nil {
+		return nil, err
+	}
+
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	last := needle[len(needle)-1]
+	for end := prevMatch(haystack, haystack.len(), last); end >= 0; end = prevMatch(haystack, end, last) {
+		result := findBefore(haystack, needle, end+1)
+		if len(result) == 0 {
+			break
+		}
+
+		if end-result[0] <= maxDistance {
+			return result, nil
+		}
+	}
+
+	return []int{}, nil
+}
+
+func findLastOccuranceWithMinimumPossibleDistance(haystack matcher, needle []string) ([]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	starts := minimumDistanceStarts(haystack, needle, true)
+	if len(starts) == 0 {
+		return []int{}, nil
+	}
+
+	window := findFrom(haystack, needle, starts[len(starts)-1])
+	return findBefore(haystack, needle, window[len(window)-1]+1), nil
+}
+
+// findBefore mirrors findFrom, matching the needle from its last element at the highest indexes
+// below end.
+func findBefore(haystack matcher, needle []string, end int) []int {
+	result := make([]int, len(needle))
+	for k := len(needle) - 1; k >= 0; k-- {
+		end = prevMatch(haystack, end, needle[k])
+		if end < 0 {
+			return []int{}
+		}
+
+		result[k] = end
+	}
+
+	return result
+}
+
+// prevMatch returns the highest index below the given one containing the element, or -1.
+func prevMatch(haystack matcher, before int, element string) int {
+	for i := before - 1; i >= 0; i-- {
+		if haystack.contains(i, element) {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,122 @@
+package algorithmictask
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastSearch(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name                                       string
+		opts                                       []Option
+		needle                                     []int
+		maxDistance                                int
+		last, maxDistanceResult, minDistanceResult []int
+		expectedError                              error
+	}{
+		{
+			name:              "needle",
+			needle:            []int{6, 5, 4},
+			maxDistance:       3,
+			last:              []int{8, 9, 10},
+			maxDistanceResult: []int{8, 9, 10},
+			minDistanceResult: []int{8, 9, 10},
+		},
+		{
+			name:              "latest_window",
+			opts:              []Option{WithIndex()},
+			needle:            []int{1, 3},
+			maxDistance:       2,
+			last:              []int{3, 9},
+			maxDistanceResult: []int{3, 4},
+			minDistanceResult: []int{3, 4},
+		},
+		{
+			name:              "no_window",
+			needle:            []int{1, 9, 3},
+			maxDistance:       1,
+			last:              []int{3, 8, 9},
+			maxDistanceResult: []int{},
+			minDistanceResult: []int{3, 5, 6},
+		},
+		{
+			name:              "no_results",
+			needle:            []int{9, 9, 9, 9, 9, 9},
+			maxDistance:       1,
+			last:              []int{},
+			maxDistanceResult: []int{},
+			minDistanceResult: []int{},
+		},
+		{
+			name:          "needle_error",
+			needle:        []int{-6},
+			maxDistance:   1,
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "distance_error",
+			needle:        []int{6},
+			expectedError: ErrDistanceMustBePositive,
+		},
+		{
+			name:          "validation_error",
+			maxDistance:   1,
+			expectedError: ErrNeedleEmpty,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			search := mustSearch(t, haystack, s.opts...)
+
+			actual, err := search.Last(s.needle)
+			if s.expectedError != ErrDistanceMustBePositive {
+				assert.Equal(t, s.last, actual)
+				assert.ErrorIs(t, err, s.expectedError)
+			}
+
+			actual, err = search.LastWithMaxDistance(s.needle, s.maxDistance)
+			assert.Equal(t, s.maxDistanceResult, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+
+			actual, err = search.LastWithMinDistance(s.needle)
+			if s.expectedError != ErrDistanceMustBePositive {
+				assert.Equal(t, s.minDistanceResult, actual)
+				assert.ErrorIs(t, err, s.expectedError)
+			}
+		})
+	}
+}
+
+func TestLastSearchMirrorsFirst(t *testing.T) {
+	for _, c := range (randomCases{n: 1000, maxLen: 30, maxNeedle: 5}).generate() {
+		haystack, needle, maxDistance := c.haystack, c.needle, c.maxDistance
+
+		// The highest match is the lowest match of the reversed needle in the reversed haystack.
+		reversed := mustSearch(t, reversedCopy(haystack))
+		search := mustSearch(t, haystack)
+		mirror := func(result []int) []int {
+			result = reversedCopy(result)
+			for k := range result {
+				result[k] = len(haystack) - 1 - result[k]
+			}
+			return result
+		}
+
+		expected, _ := reversed.First(reversedCopy(needle))
+		actual, err := search.Last(needle)
+		assert.NoError(t, err)
+		assert.Equal(t, mirror(expected), actual, "haystack: %v, needle: %v", haystack, needle)
+
+		expected, _ = reversed.FirstWithMaxDistance(reversedCopy(needle), maxDistance)
+		actual, err = search.LastWithMaxDistance(needle, maxDistance)
+		assert.NoError(t, err)
+		assert.Equal(t, mirror(expected), actual, "haystack: %v, needle: %v, maxDistance: %d", haystack, needle, maxDistance)
+
+		expected, _ = reversed.FirstWithMinDistance(reversedCopy(needle))
+		actual, err = search.LastWithMinDistance(needle)
+		assert.NoError(t, err)
+		assert.Equal(t, mirror(expected), actual, "haystack: %v, needle: %v", haystack, needle)
+	}
+}
@@ -0,0 +1,195 @@
+package algorithmictask
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var ErrInvalidLimits = errors.New("limits must not be negative")
+
+// intBytes is the memory taken by one index of a result.
+const intBytes = strconv.IntSize / 8
+
+// Limits bound the work of the Context queries, where zero means no limit.
+type Limits struct {
+	// MaxHaystackLen rejects longer haystacks before searching.
+	MaxHaystackLen int
+	// MaxCandidates stops a query before checking more haystack elements against needle
+	// elements. The Context queries check the elements one by one even WithIndex, so that each
+	// of them is counted.
+	MaxCandidates int
+	// MaxResultBytes stops a query whose results take more memory.
+	MaxResultBytes int
+}
+
+func (l Limits) validate() error {
+	if l.MaxHaystackLen < 0 || l.MaxCandidates < 0 || l.MaxResultBytes < 0 {
+		return wrapErr(ErrInvalidLimits, fmt.Sprintf("%+v", l))
+	}
+
+	return nil
+}
+
+// Limit names one of the Limits.
+type Limit int
+
+const (
+	LimitHaystackLen Limit = iota
+	LimitCandidates
+	LimitResultBytes
+)
+
+func (l Limit) String() string {
+	switch l {
+	case LimitHaystackLen:
+		return "MaxHaystackLen"
+	case LimitCandidates:
+		return "MaxCandidates"
+	}
+
+	return "MaxResultBytes"
+}
+
+// ErrLimitExceeded is returned by the Context queries when they hit one of the Limits.
+type ErrLimitExceeded struct {
+	Limit Limit
+	Max   int
+}
+
+func (e ErrLimitExceeded) Error() string {
+	return fmt.Sprintf("limit exceeded: %s %d", e.Limit, e.Max)
+}
+
+// WithLimits sets the limits of the Context queries.
+func WithLimits(limits Limits) Option {
+	return func(s *Search) {
+		s.limits = limits
+	}
+}
+
+// FirstContext is like First, but it stops with the context error when ctx is done, and with
+// ErrLimitExceeded when it hits one of the Limits.
+func (s *Search) FirstContext(ctx context.Context, needle []int) ([]int, error) {
+	return s.limited(ctx, needle, findFirstOccurance)
+}
+
+// FirstWithMaxDistanceContext is like FirstWithMaxDistance with the checks of FirstContext.
+func (s *Search) FirstWithMaxDistanceContext(ctx context.Context, needle []int, maxDistance int) ([]int, error) {
+	return s.limited(ctx, needle, func(haystack matcher, needle []string) ([]int, error) {
+		return findFirstOccuranceWithMaxDistanceLimit(haystack, needle, maxDistance)
+	})
+}
+
+// FirstWithMinDistanceContext is like FirstWithMinDistance with the checks of FirstContext.
+func (s *Search) FirstWithMinDistanceContext(ctx context.Context, needle []int) ([]int, error) {
+	return s.limited(ctx, needle, findFirstOccuranceWithMinimumPossibleDistance)
+}
+
+// AllContext collects the matches of All with the checks of FirstContext.
+func (s *Search) AllContext(ctx context.Context, needle []int) ([][]int, error) {
+	var all [][]int
+	_, err := s.limited(ctx, needle, func(haystack matcher, needle []string) ([]int, error) {
+		occurances, err := findAllOccurances(haystack, needle)
+		if err != nil {
+			return nil, err
+		}
+
+		all = [][]int{}
+		occurances(func(occurance []int) bool {
+			all = append(all, occurance)
+			return s.fitsResult(len(all) * len(needle))
+		})
+
+		return nil, nil
+	})
+	if err == nil && !s.fitsResult(len(all)*len(needle)) {
+		err = ErrLimitExceeded{Limit: LimitResultBytes, Max: s.limits.MaxResultBytes}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return all, nil
+}
+
+func (s *Search) limited(ctx context.Context, needle []int, find func(matcher, []string) ([]int, error)) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.limits.MaxHaystackLen > 0 && s.haystack.len() > s.limits.MaxHaystackLen {
+		return nil, ErrLimitExceeded{Limit: LimitHaystackLen, Max: s.limits.MaxHaystackLen}
+	}
+
+	haystack := &limitedHaystack{matcher: s.matcher(), ctx: ctx, maxCandidates: s.limits.MaxCandidates}
+	result, err := find(haystack, elements)
+	switch {
+	case haystack.err != nil:
+		return nil, haystack.err
+	case err != nil:
+		return nil, err
+	case !s.fitsResult(len(result)):
+		return nil, ErrLimitExceeded{Limit: LimitResultBytes, Max: s.limits.MaxResultBytes}
+	}
+
+	return result, nil
+}
+
+func (s *Search) fitsResult(indexes int) bool {
+	return s.limits.MaxResultBytes == 0 || indexes*intBytes <= s.limits.MaxResultBytes
+}
+
+// limitedHaystack counts the haystack elements checked and checks the context on the way. It
+// scans for the next element itself, so no element is checked past a limit. Once it stops,
+// nothing matches anymore, so every search ends early and err tells why.
+type limitedHaystack struct {
+	matcher
+	ctx           context.Context
+	maxCandidates int
+	candidates    int
+	nextCheck     int
+	err           error
+}
+
+func (h *limitedHaystack) contains(i int, element string) bool {
+	return h.check() && h.matcher.contains(i, element)
+}
+
+func (h *limitedHaystack) next(from int, element string) int {
+	for ; from < h.len(); from++ {
+		if !h.check() {
+			return h.len()
+		}
+
+		if h.matcher.contains(from, element) {
+			return from
+		}
+	}
+
+	return h.len()
+}
+
+// check reports whether one more element can be checked.
+func (h *limitedHaystack) check() bool {
+	if h.err != nil {
+		return false
+	}
+
+	if h.maxCandidates > 0 && h.candidates == h.maxCandidates {
+		h.err = ErrLimitExceeded{Limit: LimitCandidates, Max: h.maxCandidates}
+		return false
+	}
+
+	if h.candidates == h.nextCheck {
+		h.nextCheck += cancelCheckInterval
+		if h.err = h.ctx.Err(); h.err != nil {
+			return false
+		}
+	}
+
+	h.candidates++
+	return true
+}
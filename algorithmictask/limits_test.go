@@ -0,0 +1,207 @@
+package algorithmictask
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name               string
+		opts               []Option
+		needle             []int
+		first, minDistance []int
+		all                [][]int
+		expectedError      error
+	}{
+		{
+			name:        "no_limits",
+			needle:      []int{6, 5, 4},
+			first:       []int{0, 1, 4},
+			minDistance: []int{8, 9, 10},
+			all:         [][]int{{0, 1, 4}, {1, 5, 8}, {4, 5, 8}, {5, 8, 10}, {7, 8, 10}, {8, 9, 10}},
+		},
+		{
+			name:        "within_limits",
+			opts:        []Option{WithLimits(Limits{MaxHaystackLen: 11, MaxCandidates: 100, MaxResultBytes: 18 * intBytes}), WithIndex()},
+			needle:      []int{6, 5, 4},
+			first:       []int{0, 1, 4},
+			minDistance: []int{8, 9, 10},
+			all:         [][]int{{0, 1, 4}, {1, 5, 8}, {4, 5, 8}, {5, 8, 10}, {7, 8, 10}, {8, 9, 10}},
+		},
+		{
+			name:          "haystack_too_long",
+			opts:          []Option{WithLimits(Limits{MaxHaystackLen: 10})},
+			needle:        []int{6, 5, 4},
+			expectedError: ErrLimitExceeded{Limit: LimitHaystackLen, Max: 10},
+		},
+		{
+			name:          "too_many_candidates",
+			opts:          []Option{WithLimits(Limits{MaxCandidates: 3})},
+			needle:        []int{6, 5, 4},
+			expectedError: ErrLimitExceeded{Limit: LimitCandidates, Max: 3},
+		},
+		{
+			name:          "result_too_large",
+			opts:          []Option{WithLimits(Limits{MaxResultBytes: 2 * intBytes})},
+			needle:        []int{6, 5, 4},
+			expectedError: ErrLimitExceeded{Limit: LimitResultBytes, Max: 2 * intBytes},
+		},
+		{
+			name:          "invalid_limits",
+			opts:          []Option{WithLimits(Limits{MaxCandidates: -1})},
+			needle:        []int{6, 5, 4},
+			expectedError: ErrInvalidLimits,
+		},
+		{
+			name:          "needle_error",
+			needle:        []int{-1},
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "validation_error",
+			expectedError: ErrNeedleEmpty,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			search, err := NewSearch(haystack, s.opts...)
+			if err != nil {
+				assert.ErrorIs(t, err, s.expectedError)
+				return
+			}
+
+			actual, err := search.FirstContext(context.Background(), s.needle)
+			assert.Equal(t, s.first, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+
+			actual, err = search.FirstWithMinDistanceContext(context.Background(), s.needle)
+			assert.Equal(t, s.minDistance, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+
+			all, err := search.AllContext(context.Background(), s.needle)
+			assert.Equal(t, s.all, all)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestLimitsResultBytes(t *testing.T) {
+	search := mustSearch(t, []int{6, 5, 4, 6, 5, 4}, WithLimits(Limits{MaxResultBytes: 4 * intBytes}))
+
+	all, err := search.AllContext(context.Background(), []int{5, 4})
+	assert.Equal(t, [][]int{{1, 2}, {4, 5}}, all)
+	assert.NoError(t, err)
+
+	search = mustSearch(t, []int{6, 5, 4, 6, 5, 4}, WithLimits(Limits{MaxResultBytes: 3 * intBytes}))
+	all, err = search.AllContext(context.Background(), []int{5, 4})
+	assert.Equal(t, [][]int(nil), all)
+	var limitErr ErrLimitExceeded
+	assert.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, LimitResultBytes, limitErr.Limit)
+	assert.Equal(t, "limit exceeded: MaxResultBytes 24", ErrLimitExceeded{Limit: LimitResultBytes, Max: 24}.Error())
+	assert.Equal(t, "MaxHaystackLen", LimitHaystackLen.String())
+	assert.Equal(t, "MaxCandidates", LimitCandidates.String())
+}
+
+func TestLimitsCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	search := mustSearch(t, make([]int, 100000))
+	_, err := search.FirstContext(ctx, []int{1})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = search.FirstWithMaxDistanceContext(ctx, []int{1, 1}, 3)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = search.FirstWithMinDistanceContext(ctx, []int{1})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = search.AllContext(ctx, []int{1})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = search.FirstWithMaxDistanceContext(ctx, []int{1, 1}, 0)
+	assert.ErrorIs(t, err, ErrDistanceMustBePositive)
+
+	// The context is checked again after every cancelCheckInterval candidates.
+	haystack := make([]int, 3*cancelCheckInterval)
+	haystack[len(haystack)-1] = 1
+	ctx, cancel = context.WithCancel(context.Background())
+	counted := &countingMatcher{matcher: mustSearch(t, haystack).matcher()}
+	limited := &limitedHaystack{matcher: counted, ctx: ctx}
+	assert.True(t, limited.contains(0, "0"))
+	cancel()
+	assert.Equal(t, len(haystack), limited.next(0, "1"))
+	assert.Equal(t, len(haystack), limited.next(0, "0"))
+	assert.False(t, limited.contains(0, "0"))
+	assert.ErrorIs(t, limited.err, context.Canceled)
+	assert.Equal(t, cancelCheckInterval, counted.checked)
+}
+
+func TestLimitsCandidates(t *testing.T) {
+	haystack := make([]int, 1_000_000)
+	haystack[len(haystack)-1] = 1
+
+	counted := &countingMatcher{matcher: mustSearch(t, haystack, WithIndex()).matcher()}
+	limited := &limitedHaystack{matcher: counted, ctx: context.Background(), maxCandidates: 10}
+	assert.Equal(t, len(haystack), limited.next(0, "1"))
+	assert.Equal(t, ErrLimitExceeded{Limit: LimitCandidates, Max: 10}, limited.err)
+	assert.Equal(t, 10, counted.checked)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	counted = &countingMatcher{matcher: mustSearch(t, haystack).matcher()}
+	limited = &limitedHaystack{matcher: counted, ctx: ctx}
+	assert.Equal(t, len(haystack), limited.next(0, "1"))
+	assert.ErrorIs(t, limited.err, context.Canceled)
+	assert.Zero(t, counted.checked)
+
+	search := mustSearch(t, haystack, WithLimits(Limits{MaxCandidates: 10}))
+	_, err := search.FirstContext(context.Background(), []int{1})
+	assert.ErrorIs(t, err, ErrLimitExceeded{Limit: LimitCandidates, Max: 10})
+}
+
+// countingMatcher counts the haystack elements checked.
+type countingMatcher struct {
+	matcher
+	checked int
+}
+
+func (m *countingMatcher) contains(i int, element string) bool {
+	m.checked++
+	return m.matcher.contains(i, element)
+}
+
+func TestLimitsMatchSearch(t *testing.T) {
+	for _, c := range (randomCases{n: 1000, maxLen: 60, maxNeedle: 6}).generate() {
+		haystack, needle, maxDistance := c.haystack, c.needle, c.maxDistance
+
+		search := mustSearch(t, haystack)
+		limited := mustSearch(t, haystack, WithLimits(Limits{MaxHaystackLen: 60, MaxCandidates: 100000}))
+
+		expected, _ := search.First(needle)
+		actual, err := limited.FirstContext(context.Background(), needle)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v", haystack, needle)
+
+		expected, _ = search.FirstWithMaxDistance(needle, maxDistance)
+		actual, err = limited.FirstWithMaxDistanceContext(context.Background(), needle, maxDistance)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v, maxDistance: %d", haystack, needle, maxDistance)
+
+		expected, _ = search.FirstWithMinDistance(needle)
+		actual, err = limited.FirstWithMinDistanceContext(context.Background(), needle)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v", haystack, needle)
+
+		expectedAll, _ := search.Page(needle, 0, len(haystack))
+		actualAll, err := limited.AllContext(context.Background(), needle)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedAll, actualAll, "haystack: %v, needle: %v", haystack, needle)
+	}
+}
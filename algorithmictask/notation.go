@@ -0,0 +1,47 @@
+package algorithmictask
+
+import (
+	"strconv"
+	"strings"
+)
+
+// notation describes how the haystack elements are written and compared to the needle elements.
+type notation struct {
+	base int
+	sign SignMode
+}
+
+func (n notation) validate() error {
+	if n.base < 2 || n.base > 36 {
+		return wrapErr(ErrInvalidBase, strconv.Itoa(n.base))
+	}
+
+	if n.sign != SignAbsolute && n.sign != SignMatched {
+		return wrapErr(ErrInvalidSignMode, strconv.Itoa(int(n.sign)))
+	}
+
+	return nil
+}
+
+// contains reports whether the formatted haystack element contains the needle element. The
+// pattern elements match any element for ".", and any of the listed digits for a class.
+func (n notation) contains(number, element string) bool {
+	if element == anyElement {
+		return true
+	}
+
+	digits, negative := strings.CutPrefix(number, "-")
+	if n.sign == SignMatched {
+		var negativeElement bool
+		element, negativeElement = strings.CutPrefix(element, "-")
+		if negative != negativeElement {
+			return false
+		}
+	}
+
+	if class, ok := strings.CutPrefix(element, "["); ok {
+		return strings.ContainsAny(digits, strings.TrimSuffix(class, "]"))
+	}
+
+	return strings.Contains(digits, element)
+}
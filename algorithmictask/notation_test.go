@@ -0,0 +1,192 @@
+package algorithmictask
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotationContains(t *testing.T) {
+	for _, s := range []struct {
+		name            string
+		number, element string
+		sign            SignMode
+		expected        bool
+	}{
+		{name: "absolute_negative_number", number: "-12", element: "1", sign: SignAbsolute, expected: true},
+		{name: "absolute_sign_is_not_a_digit", number: "-12", element: "-1", sign: SignAbsolute, expected: false},
+		{name: "matched_negative", number: "-154", element: "-5", sign: SignMatched, expected: true},
+		{name: "matched_negative_substring", number: "-154", element: "-15", sign: SignMatched, expected: true},
+		{name: "matched_negative_needle_positive_number", number: "154", element: "-5", sign: SignMatched, expected: false},
+		{name: "matched_positive_needle_negative_number", number: "-45", element: "4", sign: SignMatched, expected: false},
+		{name: "matched_positive", number: "45", element: "4", sign: SignMatched, expected: true},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			assert.Equal(t, s.expected, notation{base: 10, sign: s.sign}.contains(s.number, s.element))
+		})
+	}
+}
+
+func TestSearchNegativeHaystack(t *testing.T) {
+	haystack := []int{-65, 154, -54, 6, -4, 45}
+
+	for _, s := range []struct {
+		name                                        string
+		opts                                        []Option
+		needle                                      []int
+		maxDistance                                 int
+		first, maxDistanceResult, minDistanceResult []int
+		expectedError                               error
+	}{
+		{
+			name:              "absolute",
+			needle:            []int{6, 5, 4},
+			maxDistance:       2,
+			first:             []int{0, 1, 2},
+			maxDistanceResult: []int{0, 1, 2},
+			minDistanceResult: []int{0, 1, 2},
+		},
+		{
+			name:              "absolute_multi_digit",
+			needle:            []int{65, 54},
+			maxDistance:       1,
+			first:             []int{0, 1},
+			maxDistanceResult: []int{0, 1},
+			minDistanceResult: []int{0, 1},
+		},
+		{
+			name:          "absolute_negative_needle",
+			needle:        []int{-1},
+			maxDistance:   1,
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:              "matched_positive_needle",
+			opts:              []Option{WithSignMode(SignMatched)},
+			needle:            []int{1, 4},
+			maxDistance:       4,
+			first:             []int{1, 5},
+			maxDistanceResult: []int{1, 5},
+			minDistanceResult: []int{1, 5},
+		},
+		{
+			name:              "matched_positive_needle_skips_negative_elements",
+			opts:              []Option{WithSignMode(SignMatched)},
+			needle:            []int{6, 5, 4},
+			maxDistance:       2,
+			first:             []int{},
+			maxDistanceResult: []int{},
+			minDistanceResult: []int{},
+		},
+		{
+			name:              "matched_negative_needle",
+			opts:              []Option{WithSignMode(SignMatched)},
+			needle:            []int{-6, -5, -4},
+			maxDistance:       4,
+			first:             []int{0, 2, 4},
+			maxDistanceResult: []int{0, 2, 4},
+			minDistanceResult: []int{0, 2, 4},
+		},
+		{
+			name:              "matched_negative_needle_max_distance",
+			opts:              []Option{WithSignMode(SignMatched)},
+			needle:            []int{-6, -5, -4},
+			maxDistance:       3,
+			first:             []int{0, 2, 4},
+			maxDistanceResult: []int{},
+			minDistanceResult: []int{0, 2, 4},
+		},
+		{
+			name:              "matched_mixed_needle",
+			opts:              []Option{WithSignMode(SignMatched), WithSingleDigitNeedle()},
+			needle:            []int{-5, 6, 4},
+			maxDistance:       3,
+			first:             []int{0, 3, 5},
+			maxDistanceResult: []int{2, 3, 5},
+			minDistanceResult: []int{2, 3, 5},
+		},
+		{
+			name:          "matched_single_digit",
+			opts:          []Option{WithSignMode(SignMatched), WithSingleDigitNeedle()},
+			needle:        []int{-12},
+			maxDistance:   1,
+			expectedError: ErrNeedleNotDigit,
+		},
+		{
+			name:          "invalid_sign_mode",
+			opts:          []Option{WithSignMode(SignMode(2))},
+			needle:        []int{1},
+			maxDistance:   1,
+			expectedError: ErrInvalidSignMode,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			if _, err := NewSearch(haystack, s.opts...); err != nil {
+				assert.ErrorIs(t, err, s.expectedError)
+				return
+			}
+
+			for _, search := range []*Search{
+				mustSearch(t, haystack, s.opts...),
+				mustSearch(t, haystack, append(s.opts, WithIndex())...),
+			} {
+				actual, err := search.First(s.needle)
+				assert.Equal(t, s.first, actual)
+				assert.ErrorIs(t, err, s.expectedError)
+
+				actual, err = search.FirstWithMaxDistance(s.needle, s.maxDistance)
+				assert.Equal(t, s.maxDistanceResult, actual)
+				assert.ErrorIs(t, err, s.expectedError)
+
+				actual, err = search.FirstWithMinDistance(s.needle)
+				assert.Equal(t, s.minDistanceResult, actual)
+				assert.ErrorIs(t, err, s.expectedError)
+			}
+		})
+	}
+}
+
+func TestSearchNegativeStringNeedle(t *testing.T) {
+	search := mustSearch(t, []int{-65, 154, -54}, WithSignMode(SignMatched))
+	actual, err := search.FirstStrings([]string{"-65", "15"})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, actual)
+
+	_, err = mustSearch(t, []int{-65}).FirstStrings([]string{"-6"})
+	assert.ErrorIs(t, err, ErrNeedleNegative)
+
+	_, err = search.FirstStrings([]string{"-"})
+	assert.ErrorIs(t, err, ErrNeedleNotDigits)
+
+	ix := NewHaystackIndex([]int{-65, 6})
+	assert.Equal(t, 2, ix.Next(0, -6))
+}
+
+func TestStreamSearchNegativeValues(t *testing.T) {
+	actual, err := FirstFromReader(strings.NewReader("-65,154,-54"), []int{6, 5, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, actual)
+
+	for _, find := range []func() ([]int, error){
+		func() ([]int, error) { return FirstFromReader(strings.NewReader("-65"), []int{-6}) },
+		func() ([]int, error) { return FirstWithMaxDistanceFromReader(strings.NewReader("-65"), []int{-6}, 1) },
+		func() ([]int, error) { return FirstWithMinDistanceFromReader(strings.NewReader("-65"), []int{-6}) },
+		func() ([]int, error) { return FirstFromSeq(func(func(int) bool) {}, []int{-6}) },
+		func() ([]int, error) { return FirstWithMaxDistanceFromSeq(func(func(int) bool) {}, []int{-6}, 1) },
+		func() ([]int, error) { return FirstWithMinDistanceFromSeq(func(func(int) bool) {}, []int{-6}) },
+	} {
+		_, err := find()
+		assert.ErrorIs(t, err, ErrNeedleNegative)
+	}
+}
+
+func TestIndexedSearchSignMatched(t *testing.T) {
+	for _, c := range (randomCases{n: 300, maxLen: 30, maxNeedle: 30, values: [2]int{-1000, 1000}, digits: [2]int{-12, 12}}).generate() {
+		haystack, needle := c.haystack, c.needle
+		expected, expectedErr := mustSearch(t, haystack, WithSignMode(SignMatched)).FirstWithMinDistance(needle)
+		actual, actualErr := mustSearch(t, haystack, WithSignMode(SignMatched), WithIndex()).FirstWithMinDistance(needle)
+		assert.Equal(t, expected, actual)
+		assert.Equal(t, expectedErr, actualErr)
+	}
+}
@@ -0,0 +1,204 @@
+package algorithmictask
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrObjectiveNil = errors.New("objective is nil")
+
+// Objective scores a match as the Combine of the Step costs of its elements, where a lower cost
+// is better. Combine must be associative and must not decrease when either argument grows, as
+// for a sum or a maximum. Optimal breaks ties with the lowest indexes.
+type Objective interface {
+	// Step returns the cost of matching the needle element at index next after index prev, or
+	// as the first element if prev is -1. number is the haystack element as it is matched.
+	Step(prev, next int, number, element string) int
+	Combine(a, b int) int
+}
+
+type objective struct {
+	step    func(prev, next int, number, element string) int
+	combine func(a, b int) int
+	// find, unless nil, returns the optimal match with a linear search.
+	find func(haystack matcher, needle []string) []int
+}
+
+func (o objective) Step(prev, next int, number, element string) int {
+	return o.step(prev, next, number, element)
+}
+
+func (o objective) Combine(a, b int) int {
+	return o.combine(a, b)
+}
+
+func (o objective) linear() func(haystack matcher, needle []string) []int {
+	return o.find
+}
+
+// linearObjective is an objective which may have a linear search of its own.
+type linearObjective interface {
+	linear() func(haystack matcher, needle []string) []int
+}
+
+var (
+	// LowestIndexes scores every match the same, so Optimal returns the same match as First.
+	LowestIndexes Objective = objective{
+		step:    func(int, int, string, string) int { return 0 },
+		combine: add,
+		find: func(haystack matcher, needle []string) []int {
+			return findFrom(haystack, needle, 0)
+		},
+	}
+	// MinDistance minimises the distance between the first and the last index, as
+	// FirstWithMinDistance does.
+	MinDistance Objective = objective{step: distanceStep, combine: add, find: lowestMinimumDistance}
+	// MinGapSum minimises the number of haystack elements between the matched ones. It is the
+	// distance minus len(needle)-1, so it picks the same match as MinDistance.
+	MinGapSum Objective = objective{step: gapStep, combine: add, find: lowestMinimumDistance}
+	// MinMaxGap minimises the largest number of haystack elements between two matched ones.
+	MinMaxGap Objective = objective{step: gapStep, combine: larger}
+	// MaxDigitOccurrences maximises how many times the needle elements occur in the matched
+	// haystack elements in total.
+	MaxDigitOccurrences Objective = objective{
+		step: func(_, _ int, number, element string) int {
+			return -strings.Count(strings.TrimPrefix(number, "-"), strings.TrimPrefix(element, "-"))
+		},
+		combine: add,
+	}
+)
+
+// Optimal returns the match with the lowest cost by the objective among every match of the
+// needle, not only the greedy ones. LowestIndexes, MinDistance and MinGapSum take the linear
+// searches of First and FirstWithMinDistance, while the other objectives compare every pair of
+// matching indexes for neighbouring needle elements, so they are quadratic in the haystack length.
+func (s *Search) Optimal(needle []int, objective Objective) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findOptimalOccurance(s.matcher(), s.haystack, s.notation.base, elements, objective)
+}
+
+func findOptimalOccurance(haystack matcher, numbers elements, base int, needle []string, objective Objective) ([]int, error) {
+	if objective == nil {
+		return nil, ErrObjectiveNil
+	}
+
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	if linear, ok := objective.(linearObjective); ok && linear.linear() != nil {
+		return linear.linear()(haystack, needle), nil
+	}
+
+	positions := make([][]int, len(needle))
+	for k, element := range needle {
+		for i := haystack.next(0, element); i < haystack.len(); i = haystack.next(i+1, element) {
+			positions[k] = append(positions[k], i)
+		}
+	}
+
+	step := func(prev, next, k int) int {
+		return objective.Step(prev, next, numbers.format(next, base), needle[k])
+	}
+
+	// costs[k][p] is the lowest cost of needle[k+1:] after needle[k] at positions[k][p], and
+	// reachable[k][p] reports whether needle[k+1:] can be matched after it at all.
+	last := len(needle) - 1
+	costs := make([][]int, len(needle))
+	reachable := make([][]bool, len(needle))
+	// with returns the lowest cost of needle[k:] with needle[k] at positions[k][p].
+	with := func(k, p, stepCost int) int {
+		if k == last {
+			return stepCost
+		}
+
+		return objective.Combine(stepCost, costs[k][p])
+	}
+
+	for k := last; k >= 0; k-- {
+		costs[k] = make([]int, len(positions[k]))
+		reachable[k] = make([]bool, len(positions[k]))
+		for p, i := range positions[k] {
+			if k == last {
+				reachable[k][p] = true
+				continue
+			}
+
+			for q, j := range positions[k+1] {
+				if j <= i || !reachable[k+1][q] {
+					continue
+				}
+
+				cost := with(k+1, q, step(i, j, k+1))
+				if !reachable[k][p] || cost < costs[k][p] {
+					costs[k][p], reachable[k][p] = cost, true
+				}
+			}
+		}
+	}
+
+	optimal, found := 0, false
+	for p, i := range positions[0] {
+		if cost := with(0, p, step(-1, i, 0)); reachable[0][p] && (!found || cost < optimal) {
+			optimal, found = cost, true
+		}
+	}
+
+	if !found {
+		return []int{}, nil
+	}
+
+	// Each needle element takes the lowest index which still allows the optimal cost.
+	result := make([]int, 0, len(needle))
+	prefix, prev := 0, -1
+	for k := range needle {
+		for p, i := range positions[k] {
+			if i <= prev || !reachable[k][p] {
+				continue
+			}
+
+			stepCost := step(prev, i, k)
+			cost := with(k, p, stepCost)
+			if k > 0 {
+				cost = objective.Combine(prefix, cost)
+				stepCost = objective.Combine(prefix, stepCost)
+			}
+
+			if cost == optimal {
+				result = append(result, i)
+				prefix, prev = stepCost, i
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func distanceStep(prev, next int, _, _ string) int {
+	if prev < 0 {
+		return 0
+	}
+
+	return next - prev
+}
+
+func gapStep(prev, next int, _, _ string) int {
+	if prev < 0 {
+		return 0
+	}
+
+	return next - prev - 1
+}
+
+func add(a, b int) int {
+	return a + b
+}
+
+func larger(a, b int) int {
+	return max(a, b)
+}
@@ -0,0 +1,167 @@
+package algorithmictask
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"solvencyanalytics/algorithmictask/subsequence"
+)
+
+func TestOptimal(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name          string
+		haystack      []int
+		needle        []int
+		objective     Objective
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:      "lowest_indexes",
+			haystack:  haystack,
+			needle:    []int{6, 5, 4},
+			objective: LowestIndexes,
+			expected:  []int{0, 1, 4},
+		},
+		{
+			name:      "min_distance",
+			haystack:  haystack,
+			needle:    []int{6, 5, 4},
+			objective: MinDistance,
+			expected:  []int{8, 9, 10},
+		},
+		{
+			name:      "min_gap_sum",
+			haystack:  haystack,
+			needle:    []int{6, 5, 4},
+			objective: MinGapSum,
+			expected:  []int{8, 9, 10},
+		},
+		{
+			name:      "min_max_gap",
+			haystack:  []int{6, 5, 1, 1, 4, 6, 1, 5, 1, 4},
+			needle:    []int{6, 5, 4},
+			objective: MinMaxGap,
+			expected:  []int{5, 7, 9},
+		},
+		{
+			name:      "min_distance_of_min_max_gap",
+			haystack:  []int{6, 5, 1, 1, 4, 6, 1, 5, 1, 4},
+			needle:    []int{6, 5, 4},
+			objective: MinDistance,
+			expected:  []int{0, 1, 4},
+		},
+		{
+			name:      "max_digit_occurrences",
+			haystack:  []int{6, 66, 5, 55, 4},
+			needle:    []int{6, 5, 4},
+			objective: MaxDigitOccurrences,
+			expected:  []int{1, 3, 4},
+		},
+		{
+			name:     "custom",
+			haystack: []int{6, 5, 4, 16, 15, 14},
+			needle:   []int{6, 5, 4},
+			objective: objective{
+				step:    func(_, next int, _, _ string) int { return -next },
+				combine: add,
+			},
+			expected: []int{3, 4, 5},
+		},
+		{
+			name:      "no_results",
+			haystack:  haystack,
+			needle:    []int{9, 9, 9, 9, 9, 9},
+			objective: MinMaxGap,
+			expected:  []int{},
+		},
+		{
+			name:          "nil_objective",
+			haystack:      haystack,
+			needle:        []int{6, 5, 4},
+			expectedError: ErrObjectiveNil,
+		},
+		{
+			name:          "needle_error",
+			haystack:      haystack,
+			needle:        []int{-6},
+			objective:     MinDistance,
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "validation_error",
+			haystack:      haystack,
+			objective:     MinDistance,
+			expectedError: ErrNeedleEmpty,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := mustSearch(t, s.haystack).Optimal(s.needle, s.objective)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestOptimalLinearObjectives(t *testing.T) {
+	// Every pair of indexes matches, so only the linear searches finish in time.
+	haystack := make([]int, 1_000_000)
+	for i := range haystack {
+		haystack[i] = 1
+	}
+	search := mustSearch(t, haystack)
+
+	actual, err := search.Optimal([]int{1, 1, 1}, LowestIndexes)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 2}, actual)
+
+	for _, objective := range []Objective{MinDistance, MinGapSum} {
+		actual, err = search.Optimal([]int{1, 1, 1}, objective)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2}, actual)
+	}
+}
+
+func TestOptimalOracle(t *testing.T) {
+	for _, c := range (randomCases{n: 300, maxLen: 12, maxNeedle: 5}).generate() {
+		haystack, needle := c.haystack, c.needle
+		search := mustSearch(t, haystack, WithIndex())
+
+		for _, objective := range []Objective{LowestIndexes, MinDistance, MinGapSum, MinMaxGap, MaxDigitOccurrences} {
+			// The matches are enumerated in lexicographic order, so the first lowest cost wins.
+			expected, best := []int{}, 0
+			var walk func(result []int, from, cost int)
+			walk = func(result []int, from, cost int) {
+				if len(result) == len(needle) {
+					if len(expected) == 0 || cost < best {
+						expected, best = append([]int{}, result...), cost
+					}
+					return
+				}
+				for i := from; i < len(haystack); i++ {
+					element := strconv.Itoa(needle[len(result)])
+					if !subsequence.Digits(haystack[i], element) {
+						continue
+					}
+					prev := -1
+					if len(result) > 0 {
+						prev = result[len(result)-1]
+					}
+					step := objective.Step(prev, i, strconv.Itoa(haystack[i]), element)
+					if len(result) > 0 {
+						step = objective.Combine(cost, step)
+					}
+					walk(append(result, i), i+1, step)
+				}
+			}
+			walk(nil, 0, 0)
+
+			actual, err := search.Optimal(needle, objective)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, actual, "haystack: %v, needle: %v", haystack, needle)
+		}
+	}
+}
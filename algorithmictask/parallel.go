@@ -0,0 +1,275 @@
+package algorithmictask
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+var ErrInvalidParallelism = errors.New("parallelism must not be negative")
+
+const (
+	// shardsPerWorker splits the haystack into more shards than workers, so the early shards
+	// are stitched while the later ones are still scanned.
+	shardsPerWorker = 4
+	// cancelCheckInterval is the number of elements scanned between two context checks.
+	cancelCheckInterval = 4096
+)
+
+// minShardSize keeps the shards large enough to be worth a goroutine and a summary.
+var minShardSize = 1 << 14
+
+// WithParallelism sets the number of workers scanning the shards of the haystack in the
+// parallel queries, at most runtime.GOMAXPROCS, which is also the default for 0.
+func WithParallelism(workers int) Option {
+	return func(s *Search) {
+		s.workers = workers
+	}
+}
+
+// FirstParallel returns the same result as First, scanning the shards of the haystack
+// concurrently. It stops with the context error when ctx is done.
+func (s *Search) FirstParallel(ctx context.Context, needle []int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceInParallel(ctx, s.matcher(), elements, s.parallelism())
+}
+
+// FirstWithMinDistanceParallel returns the same result as FirstWithMinDistance, scanning the
+// shards of the haystack concurrently. It stops with the context error when ctx is done.
+func (s *Search) FirstWithMinDistanceParallel(ctx context.Context, needle []int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceWithMinimumPossibleDistanceInParallel(ctx, s.matcher(), elements, s.parallelism())
+}
+
+func (s *Search) parallelism() int {
+	if s.workers == 0 {
+		return runtime.GOMAXPROCS(0)
+	}
+
+	return min(s.workers, runtime.GOMAXPROCS(0))
+}
+
+// shardCount splits n elements into shards of at least minShardSize elements, and returns
+// how many of the workers get one.
+func shardCount(n, workers int) (int, int) {
+	shards := max(1, min(n/minShardSize, workers*shardsPerWorker))
+	return shards, min(workers, shards)
+}
+
+// shardSummary describes how a shard changes the starts of minimumDistanceStarts without
+// knowing the starts before the shard. A start is either an index in the shard, or a negative
+// -1-k referring to the start of needle[:k+1] before the shard.
+type shardSummary struct {
+	// starts holds the starts after the shard.
+	starts []int
+	// firstSet[k][j] is the first index where the start of needle[:k+1] was set to the start
+	// before the shard of needle[:j+1], or to an index in the shard for j == len(needle), or -1.
+	firstSet [][]int
+	// minStart and minEnd are the first match with the smallest distance within the shard, or -1.
+	minStart, minEnd int
+}
+
+// startBefore returns the start referred to by the k-th shard start, or the start itself.
+func startBefore(start int, starts []int) int {
+	if start >= 0 {
+		return start
+	}
+
+	return starts[-1-start]
+}
+
+func findFirstOccuranceInParallel(ctx context.Context, haystack matcher, needle []string, workers int) ([]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	// matched needle elements are set at the first index where their prefix match ends, which
+	// is the lowest index First picks for them.
+	result, matched := make([]int, len(needle)), 0
+	err := summarizeShards(ctx, haystack, needle, workers, func(summary *shardSummary) bool {
+		// only the starts of the prefixes matched before the shard refer to an index
+		before := matched
+		for ; matched < len(needle); matched++ {
+			result[matched] = summary.firstSet[matched][len(needle)]
+			for _, i := range summary.firstSet[matched][:before] {
+				if i >= 0 && (result[matched] < 0 || i < result[matched]) {
+					result[matched] = i
+				}
+			}
+
+			if result[matched] < 0 {
+				break
+			}
+		}
+
+		return matched < len(needle)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if matched < len(needle) {
+		return []int{}, nil
+	}
+
+	return result, nil
+}
+
+func findFirstOccuranceWithMinimumPossibleDistanceInParallel(ctx context.Context, haystack matcher, needle []string, workers int) ([]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	last := len(needle) - 1
+	starts := make([]int, len(needle))
+	for k := range starts {
+		starts[k] = -1
+	}
+
+	minStart, minDistance := -1, haystack.len()
+	err := summarizeShards(ctx, haystack, needle, workers, func(summary *shardSummary) bool {
+		start, end := summary.minStart, summary.minEnd
+		for j, i := range summary.firstSet[last][:len(needle)] {
+			if i >= 0 && starts[j] >= 0 && (start < 0 || i-starts[j] < end-start || (i-starts[j] == end-start && i < end)) {
+				start, end = starts[j], i
+			}
+		}
+
+		if start >= 0 && end-start < minDistance {
+			minStart, minDistance = start, end-start
+		}
+
+		after := make([]int, len(needle))
+		for k, start := range summary.starts {
+			after[k] = startBefore(start, starts)
+		}
+		starts = after
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if minStart < 0 {
+		return []int{}, nil
+	}
+
+	return findFrom(haystack, needle, minStart), nil
+}
+
+// summarizeShards summarizes the shards of the haystack with a bounded pool of workers, and
+// passes the summaries to stitch in the order of the shards until it returns false.
+func summarizeShards(ctx context.Context, haystack matcher, needle []string, workers int, stitch func(*shardSummary) bool) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	n := haystack.len()
+	shards, workers := shardCount(n, workers)
+	size := (n + shards - 1) / shards
+	summaries := make([]*shardSummary, shards)
+	errs := make([]error, shards)
+	done := make([]chan struct{}, shards)
+	for shard := range done {
+		done[shard] = make(chan struct{})
+	}
+
+	jobs := make(chan int)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(jobs)
+		// the shards left after a cancellation return as soon as they check the context
+		for shard := 0; shard < shards; shard++ {
+			jobs <- shard
+		}
+	}()
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for shard := range jobs {
+				from := shard * size
+				summaries[shard], errs[shard] = summarizeShard(ctx, haystack, needle, from, min(from+size, n))
+				close(done[shard])
+			}
+		}()
+	}
+
+	for shard := 0; shard < shards; shard++ {
+		<-done[shard]
+		if errs[shard] != nil {
+			return errs[shard]
+		}
+
+		if !stitch(summaries[shard]) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// summarizeShard runs the dynamic programming of minimumDistanceStarts on the indexes from
+// start to end, with the starts before the shard left as references.
+func summarizeShard(ctx context.Context, haystack matcher, needle []string, start, end int) (*shardSummary, error) {
+	summary := &shardSummary{
+		starts:   make([]int, len(needle)),
+		firstSet: make([][]int, len(needle)),
+		minStart: -1,
+		minEnd:   -1,
+	}
+	for k := range needle {
+		summary.starts[k] = -1 - k
+		summary.firstSet[k] = make([]int, len(needle)+1)
+		for j := range summary.firstSet[k] {
+			summary.firstSet[k][j] = -1
+		}
+	}
+
+	last := len(needle) - 1
+	for i := start; i < end; i++ {
+		if (i-start)%cancelCheckInterval == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		for k := last; k >= 0; k-- {
+			if !haystack.contains(i, needle[k]) {
+				continue
+			}
+
+			if k == 0 {
+				summary.starts[k] = i
+			} else {
+				summary.starts[k] = summary.starts[k-1]
+			}
+
+			source := len(needle)
+			if summary.starts[k] < 0 {
+				source = -1 - summary.starts[k]
+			}
+			if summary.firstSet[k][source] < 0 {
+				summary.firstSet[k][source] = i
+			}
+
+			if k == last && summary.starts[k] >= 0 && (summary.minStart < 0 || i-summary.starts[k] < summary.minEnd-summary.minStart) {
+				summary.minStart, summary.minEnd = summary.starts[k], i
+			}
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,175 @@
+package algorithmictask
+
+import (
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// smallShards lets the short test haystacks span several shards.
+func smallShards(t *testing.T) {
+	bkp := minShardSize
+	t.Cleanup(func() {
+		minShardSize = bkp
+	})
+	minShardSize = 1
+}
+
+func TestParallelSearch(t *testing.T) {
+	smallShards(t)
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name               string
+		opts               []Option
+		needle             []int
+		first, minDistance []int
+		expectedError      error
+	}{
+		{
+			name:        "default_parallelism",
+			needle:      []int{6, 5, 4},
+			first:       []int{0, 1, 4},
+			minDistance: []int{8, 9, 10},
+		},
+		{
+			name:        "single_worker",
+			opts:        []Option{WithParallelism(1)},
+			needle:      []int{6, 5, 4},
+			first:       []int{0, 1, 4},
+			minDistance: []int{8, 9, 10},
+		},
+		{
+			name:        "more_workers_than_elements",
+			opts:        []Option{WithParallelism(16), WithIndex()},
+			needle:      []int{6, 5, 4},
+			first:       []int{0, 1, 4},
+			minDistance: []int{8, 9, 10},
+		},
+		{
+			name:        "match_across_shards",
+			opts:        []Option{WithParallelism(3)},
+			needle:      []int{1, 9, 0, 4},
+			first:       []int{1, 4, 5, 8},
+			minDistance: []int{3, 4, 5, 8},
+		},
+		{
+			name:        "no_results",
+			opts:        []Option{WithParallelism(2)},
+			needle:      []int{9, 9, 9, 9, 9, 9},
+			first:       []int{},
+			minDistance: []int{},
+		},
+		{
+			name:          "invalid_parallelism",
+			opts:          []Option{WithParallelism(-1)},
+			needle:        []int{6, 5, 4},
+			expectedError: ErrInvalidParallelism,
+		},
+		{
+			name:          "validation_error",
+			expectedError: ErrNeedleEmpty,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			search, err := NewSearch(haystack, s.opts...)
+			if err != nil {
+				assert.ErrorIs(t, err, s.expectedError)
+				return
+			}
+
+			actual, err := search.FirstParallel(context.Background(), s.needle)
+			assert.Equal(t, s.first, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+
+			actual, err = search.FirstWithMinDistanceParallel(context.Background(), s.needle)
+			assert.Equal(t, s.minDistance, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestShardCount(t *testing.T) {
+	for _, s := range []struct {
+		name            string
+		n, workers      int
+		shards, working int
+	}{
+		{name: "below_min_shard_size", n: 11, workers: 8, shards: 1, working: 1},
+		{name: "shards_per_worker", n: 100 * minShardSize, workers: 8, shards: 32, working: 8},
+		{name: "limited_by_min_shard_size", n: 10 * minShardSize, workers: 8, shards: 10, working: 8},
+		{name: "few_workers_for_many_shards", n: 10 * minShardSize, workers: 2, shards: 8, working: 2},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			shards, working := shardCount(s.n, s.workers)
+			assert.Equal(t, s.shards, shards)
+			assert.Equal(t, s.working, working)
+		})
+	}
+
+	search := mustSearch(t, []int{1, 2, 3}, WithParallelism(1<<62))
+	assert.Equal(t, runtime.GOMAXPROCS(0), search.parallelism())
+}
+
+func TestParallelSearchCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	search := mustSearch(t, make([]int, 100000), WithParallelism(4))
+	_, err := search.FirstParallel(ctx, []int{1})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = search.FirstWithMinDistanceParallel(ctx, []int{1})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, err = search.FirstWithMinDistanceParallel(ctx, []int{-1})
+	assert.ErrorIs(t, err, ErrNeedleNegative)
+
+	_, err = search.FirstParallel(ctx, []int{-1})
+	assert.ErrorIs(t, err, ErrNeedleNegative)
+}
+
+func TestParallelSearchMatchesSearch(t *testing.T) {
+	smallShards(t)
+	for _, c := range (randomCases{n: 1000, maxLen: 60, maxNeedle: 6}).generate() {
+		haystack, needle := c.haystack, c.needle
+
+		search := mustSearch(t, haystack)
+		parallel := mustSearch(t, haystack, WithParallelism(1+c.rnd.Intn(8)))
+
+		expected, _ := search.First(needle)
+		actual, err := parallel.FirstParallel(context.Background(), needle)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v", haystack, needle)
+
+		expected, _ = search.FirstWithMinDistance(needle)
+		actual, err = parallel.FirstWithMinDistanceParallel(context.Background(), needle)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v", haystack, needle)
+	}
+}
+
+func BenchmarkParallelSearch(b *testing.B) {
+	haystack := make([]int, 1_000_000)
+	for i := range haystack {
+		haystack[i] = 111
+	}
+	haystack[len(haystack)-2] = 6
+	haystack[len(haystack)-1] = 54
+	needle := []int{6, 5, 4}
+	search := mustSearch(b, haystack)
+
+	b.Run("sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			search.FirstWithMinDistance(needle)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			search.FirstWithMinDistanceParallel(context.Background(), needle)
+		}
+	})
+}
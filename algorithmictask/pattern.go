@@ -0,0 +1,161 @@
+package algorithmictask
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// anyElement is the needle element of the "." pattern wildcard.
+const anyElement = "."
+
+// FirstPattern is like First for a needle written as a pattern of one element per position:
+//   - a digit in the search base, like 5,
+//   - a class of digits like [135] or [1-3], matching an element containing any of them,
+//   - a negated class like [^0], matching an element containing any other digit,
+//   - a "." matching any element.
+//
+// An element followed by ? is optional. It takes the lowest index which still leaves a match for
+// the rest of the pattern, and is marked with Skipped if there is none.
+func (s *Search) FirstPattern(pattern string) ([]int, error) {
+	elements, optional, err := compilePattern(strings.ToLower(pattern), s.notation.base)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstPatternOccurance(s.matcher(), elements, optional)
+}
+
+// compilePattern returns a needle element for each position of the pattern, with the classes
+// written out as the list of their digits, like [135], and whether each position is optional.
+func compilePattern(pattern string, base int) ([]string, []bool, error) {
+	var elements []string
+	var optional []bool
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '?':
+			if len(elements) == 0 || optional[len(optional)-1] {
+				return nil, nil, wrapErr(ErrPatternMisplacedOptional, fmt.Sprintf("at offset %d", i))
+			}
+			optional[len(optional)-1] = true
+			continue
+		case c == '.':
+			elements = append(elements, anyElement)
+		case c == '[':
+			class, end, err := compileClass(pattern, i, base)
+			if err != nil {
+				return nil, nil, err
+			}
+			elements, i = append(elements, class), end
+		case digitValue(c) < base:
+			elements = append(elements, string(c))
+		default:
+			return nil, nil, wrapErr(ErrPatternInvalidDigit, fmt.Sprintf("%q at offset %d", c, i))
+		}
+		optional = append(optional, false)
+	}
+
+	if len(elements) == 0 {
+		return nil, nil, ErrNeedleEmpty
+	}
+
+	return elements, optional, nil
+}
+
+// compileClass compiles the class starting at the given offset, and returns it with the offset
+// of its closing bracket.
+func compileClass(pattern string, start, base int) (string, int, error) {
+	i := start + 1
+	negated := i < len(pattern) && pattern[i] == '^'
+	if negated {
+		i++
+	}
+
+	in := make([]bool, base)
+	for ; i < len(pattern) && pattern[i] != ']'; i++ {
+		from := digitValue(pattern[i])
+		if from >= base {
+			return "", 0, wrapErr(ErrPatternInvalidDigit, fmt.Sprintf("%q at offset %d", pattern[i], i))
+		}
+
+		to := from
+		if i+2 < len(pattern) && pattern[i+1] == '-' && pattern[i+2] != ']' {
+			to = digitValue(pattern[i+2])
+			if to >= base {
+				return "", 0, wrapErr(ErrPatternInvalidDigit, fmt.Sprintf("%q at offset %d", pattern[i+2], i+2))
+			}
+			if to < from {
+				return "", 0, wrapErr(ErrPatternReversedRange, fmt.Sprintf("%q at offset %d", pattern[i:i+3], i))
+			}
+			i += 2
+		}
+
+		for digit := from; digit <= to; digit++ {
+			in[digit] = true
+		}
+	}
+
+	if i == len(pattern) {
+		return "", 0, wrapErr(ErrPatternUnclosedClass, fmt.Sprintf("at offset %d", start))
+	}
+
+	var class strings.Builder
+	class.WriteByte('[')
+	for digit, ok := range in {
+		if ok != negated {
+			class.WriteString(strconv.FormatInt(int64(digit), base))
+		}
+	}
+	class.WriteByte(']')
+
+	if class.Len() == 2 {
+		return "", 0, wrapErr(ErrPatternEmptyClass, fmt.Sprintf("%q at offset %d", pattern[start:i+1], start))
+	}
+
+	return class.String(), i, nil
+}
+
+func findFirstPatternOccurance(haystack matcher, needle []string, optional []bool) ([]int, error) {
+	required := 0
+	for _, ok := range optional {
+		if !ok {
+			required++
+		}
+	}
+
+	if haystack.len() == 0 {
+		return nil, ErrHaystackEmpty
+	}
+
+	if haystack.len() < required {
+		return nil, ErrHaystackShorter
+	}
+
+	// latest[p] is the highest index from which needle[p:] can still be matched, or -1.
+	latest := make([]int, len(needle)+1)
+	latest[len(needle)] = haystack.len()
+	for p := len(needle) - 1; p >= 0; p-- {
+		latest[p] = prevMatch(haystack, latest[p+1], needle[p])
+		if optional[p] {
+			latest[p] = max(latest[p], latest[p+1])
+		}
+	}
+
+	if latest[0] < 0 {
+		return []int{}, nil
+	}
+
+	result := make([]int, len(needle))
+	cursor := 0
+	for p, element := range needle {
+		result[p] = haystack.next(cursor, element)
+		if result[p] >= latest[p+1] {
+			result[p] = Skipped
+			continue
+		}
+
+		cursor = result[p] + 1
+	}
+
+	return result, nil
+}
@@ -0,0 +1,245 @@
+package algorithmictask
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirstPattern(t *testing.T) {
+	haystack := []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664}
+
+	for _, s := range []struct {
+		name          string
+		haystack      []int
+		opts          []Option
+		pattern       string
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:     "digits",
+			haystack: haystack,
+			pattern:  "654",
+			expected: []int{0, 1, 4},
+		},
+		{
+			name:     "range_digit_wildcard",
+			haystack: haystack,
+			pattern:  "[1-3]5.",
+			expected: []int{0, 1, 2},
+		},
+		{
+			name:     "class",
+			haystack: haystack,
+			pattern:  "[98][98]",
+			expected: []int{2, 4},
+		},
+		{
+			name:     "negated_class",
+			haystack: []int{0, 100, 7},
+			pattern:  "[^0]",
+			expected: []int{1},
+		},
+		{
+			name:     "optional",
+			haystack: haystack,
+			pattern:  "6?9",
+			expected: []int{0, 4},
+		},
+		{
+			name:     "optional_skipped",
+			haystack: []int{9, 6},
+			pattern:  "6?9",
+			expected: []int{Skipped, 0},
+		},
+		{
+			name:     "base",
+			haystack: []int{0xab, 0x1f},
+			opts:     []Option{WithBase(16)},
+			pattern:  "[A-C]F",
+			expected: []int{0, 1},
+		},
+		{
+			name:     "sign_matched",
+			haystack: []int{-15, 15},
+			opts:     []Option{WithSignMode(SignMatched), WithIndex()},
+			pattern:  "[5]",
+			expected: []int{1},
+		},
+		{
+			name:     "no_results",
+			haystack: haystack,
+			pattern:  "99999999",
+			expected: []int{},
+		},
+		{
+			name:          "empty",
+			haystack:      haystack,
+			pattern:       "",
+			expectedError: ErrNeedleEmpty,
+		},
+		{
+			name:          "unclosed_class",
+			haystack:      haystack,
+			pattern:       "1[23",
+			expectedError: ErrPatternUnclosedClass,
+		},
+		{
+			name:          "empty_class",
+			haystack:      haystack,
+			pattern:       "[^0-9]",
+			expectedError: ErrPatternEmptyClass,
+		},
+		{
+			name:          "reversed_range",
+			haystack:      haystack,
+			pattern:       "[3-1]",
+			expectedError: ErrPatternReversedRange,
+		},
+		{
+			name:          "invalid_digit",
+			haystack:      haystack,
+			pattern:       "1a",
+			expectedError: ErrPatternInvalidDigit,
+		},
+		{
+			name:          "invalid_class_digit",
+			haystack:      haystack,
+			pattern:       "[1a]",
+			expectedError: ErrPatternInvalidDigit,
+		},
+		{
+			name:          "invalid_range_digit",
+			haystack:      haystack,
+			pattern:       "[1-a]",
+			expectedError: ErrPatternInvalidDigit,
+		},
+		{
+			name:          "leading_optional",
+			haystack:      haystack,
+			pattern:       "?1",
+			expectedError: ErrPatternMisplacedOptional,
+		},
+		{
+			name:          "double_optional",
+			haystack:      haystack,
+			pattern:       "1??",
+			expectedError: ErrPatternMisplacedOptional,
+		},
+		{
+			name:          "haystack_is_empty",
+			pattern:       "1",
+			expectedError: ErrHaystackEmpty,
+		},
+		{
+			name:          "haystack_is_shorter",
+			haystack:      []int{1},
+			pattern:       "1.?1",
+			expectedError: ErrHaystackShorter,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := mustSearch(t, s.haystack, s.opts...).FirstPattern(s.pattern)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestFirstPatternOracle(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 1000; n++ {
+		haystack := make([]int, 1+rnd.Intn(10))
+		for i := range haystack {
+			haystack[i] = rnd.Intn(1000)
+		}
+
+		// Each position is a set of digits, where all of them stand for ".".
+		var pattern strings.Builder
+		var sets []string
+		var optional []bool
+		for p := 0; p < 1+rnd.Intn(4); p++ {
+			switch rnd.Intn(3) {
+			case 0:
+				digit := strconv.Itoa(rnd.Intn(10))
+				pattern.WriteString(digit)
+				sets = append(sets, digit)
+			case 1:
+				from, to := rnd.Intn(10), rnd.Intn(10)
+				from, to = min(from, to), max(from, to)
+				set := ""
+				for digit := from; digit <= to; digit++ {
+					set += strconv.Itoa(digit)
+				}
+				pattern.WriteString("[" + strconv.Itoa(from) + "-" + strconv.Itoa(to) + "]")
+				sets = append(sets, set)
+			default:
+				pattern.WriteString(".")
+				sets = append(sets, "")
+			}
+			optional = append(optional, rnd.Intn(3) == 0)
+			if optional[p] {
+				pattern.WriteString("?")
+			}
+		}
+
+		// The lowest result prefers a matched position over a skipped one, then the lowest index.
+		var expected []int
+		var walk func(result []int, from int)
+		walk = func(result []int, from int) {
+			if len(result) == len(sets) {
+				if expected == nil || patternLess(result, expected) {
+					expected = append([]int{}, result...)
+				}
+				return
+			}
+			p := len(result)
+			for i := from; i < len(haystack); i++ {
+				if sets[p] == "" || strings.ContainsAny(strconv.Itoa(haystack[i]), sets[p]) {
+					walk(append(result, i), i+1)
+				}
+			}
+			if optional[p] {
+				walk(append(result, Skipped), from)
+			}
+		}
+		walk(nil, 0)
+		if expected == nil {
+			expected = []int{}
+		}
+
+		required := 0
+		for _, ok := range optional {
+			if !ok {
+				required++
+			}
+		}
+		if required > len(haystack) {
+			continue
+		}
+
+		actual, err := mustSearch(t, haystack, WithIndex()).FirstPattern(pattern.String())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, pattern: %s", haystack, pattern.String())
+	}
+}
+
+func patternLess(a, b []int) bool {
+	for p := range a {
+		switch {
+		case a[p] == b[p]:
+		case b[p] == Skipped:
+			return true
+		case a[p] == Skipped:
+			return false
+		default:
+			return a[p] < b[p]
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,302 @@
+package algorithmictask
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"solvencyanalytics/algorithmictask/subsequence"
+)
+
+var ErrInvalidElement = errors.New("invalid haystack element")
+
+// stream feeds haystack elements to yield until it returns false or the source is drained.
+type stream func(yield func(int) bool) error
+
+// FirstFromReader is like Search.First, but reads a newline or comma separated haystack and
+// stops reading as soon as the match is found.
+func FirstFromReader(r io.Reader, needle []int) ([]int, error) {
+	elements, err := decimalNeedle(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceInStream(readerStream(r), elements)
+}
+
+// FirstWithMaxDistanceFromReader is like Search.FirstWithMaxDistance, but reads a newline or
+// comma separated haystack. ErrDistanceTooLarge is not reported since the haystack length is
+// unknown upfront.
+func FirstWithMaxDistanceFromReader(r io.Reader, needle []int, maxDistance int) ([]int, error) {
+	elements, err := decimalNeedle(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceWithMaxDistanceLimitInStream(readerStream(r), elements, maxDistance)
+}
+
+// FirstWithMinDistanceFromReader is like Search.FirstWithMinDistance, but reads a newline or
+// comma separated haystack. It stops reading only when no shorter match is possible.
+func FirstWithMinDistanceFromReader(r io.Reader, needle []int) ([]int, error) {
+	elements, err := decimalNeedle(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceWithMinimumPossibleDistanceInStream(readerStream(r), elements)
+}
+
+func FirstFromSeq(haystack Seq[int], needle []int) ([]int, error) {
+	elements, err := decimalNeedle(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceInStream(seqStream(haystack), elements)
+}
+
+func FirstWithMaxDistanceFromSeq(haystack Seq[int], needle []int, maxDistance int) ([]int, error) {
+	elements, err := decimalNeedle(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceWithMaxDistanceLimitInStream(seqStream(haystack), elements, maxDistance)
+}
+
+func FirstWithMinDistanceFromSeq(haystack Seq[int], needle []int) ([]int, error) {
+	elements, err := decimalNeedle(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceWithMinimumPossibleDistanceInStream(seqStream(haystack), elements)
+}
+
+// decimalNeedle formats the needle of the streamed searches, which always use SignAbsolute.
+func decimalNeedle(needle []int) ([]string, error) {
+	for _, digit := range needle {
+		if digit < 0 {
+			return nil, wrapErr(ErrNeedleNegative, strconv.Itoa(digit))
+		}
+	}
+
+	return digitStrings(needle), nil
+}
+
+func findFirstOccuranceInStream(haystack stream, needle []string) ([]int, error) {
+	if len(needle) == 0 {
+		return nil, ErrNeedleEmpty
+	}
+
+	var i int
+	result := make([]int, 0, len(needle))
+	err := haystack(func(number int) bool {
+		if subsequence.Digits(number, needle[len(result)]) {
+			result = append(result, i)
+		}
+		i++
+
+		return len(result) < len(needle)
+	})
+
+	return streamResult(result, i, len(needle), err)
+}
+
+func findFirstOccuranceWithMaxDistanceLimitInStream(haystack stream, needle []string, maxDistance int) ([]int, error) {
+	if maxDistance <= 0 {
+		return nil, ErrDistanceMustBePositive
+	}
+
+	if len(needle) == 0 {
+		return nil, ErrNeedleEmpty
+	}
+
+	// window holds the elements from the current start candidate, so it never grows beyond
+	// maxDistance+1 elements.
+	var count, start int
+	var window []int
+	result := make([]int, 0, len(needle))
+	err := haystack(func(number int) bool {
+		i := count
+		count++
+
+		if len(window) == 0 {
+			if !subsequence.Digits(number, needle[0]) {
+				return true
+			}
+			start = i
+		}
+
+		window = append(window, number)
+		if subsequence.Digits(number, needle[len(result)]) {
+			result = append(result, i)
+		}
+
+		for len(result) < len(needle) && i-start >= maxDistance {
+			window = window[1:]
+			for len(window) > 0 && !subsequence.Digits(window[0], needle[0]) {
+				window = window[1:]
+			}
+			start = i - len(window) + 1
+			result = findInWindow(window, needle, start, result[:0])
+		}
+
+		return len(result) < len(needle)
+	})
+
+	return streamResult(result, count, len(needle), err)
+}
+
+func findInWindow(window []int, needle []string, start int, result []int) []int {
+	for j, number := range window {
+		if len(result) < len(needle) && subsequence.Digits(number, needle[len(result)]) {
+			result = append(result, start+j)
+		}
+	}
+
+	return result
+}
+
+// matchNode is one index of a partial match, linked to the index of the previous needle digit.
+type matchNode struct {
+	index, start int
+	prev         *matchNode
+}
+
+func findFirstOccuranceWithMinimumPossibleDistanceInStream(haystack stream, needle []string) ([]int, error) {
+	if len(needle) == 0 {
+		return nil, ErrNeedleEmpty
+	}
+
+	state := newMinimumDistanceState(needle)
+	var i int
+	err := haystack(func(number int) bool {
+		state.add(i, number)
+		i++
+
+		return !state.done()
+	})
+
+	return streamResult(state.result(), i, len(needle), err)
+}
+
+// minimumDistanceState finds the match with the smallest distance one element at a time.
+type minimumDistanceState struct {
+	needle []string
+	// matches[k] is the lowest match of needle[:k+1] with the latest start seen so far. The
+	// partial matches share their prefixes, so memory stays bounded by the needle length.
+	matches []*matchNode
+	best    *matchNode
+}
+
+func newMinimumDistanceState(needle []string) *minimumDistanceState {
+	return &minimumDistanceState{needle: needle, matches: make([]*matchNode, len(needle))}
+}
+
+// add matches the i-th element, and reports whether it changed the best match.
+func (s *minimumDistanceState) add(i, number int) bool {
+	last := len(s.needle) - 1
+	changed := false
+	for k := last; k >= 0; k-- {
+		if !subsequence.Digits(number, s.needle[k]) {
+			continue
+		}
+
+		switch {
+		case k == 0:
+			s.matches[k] = &matchNode{index: i, start: i}
+		case s.matches[k-1] != nil && (s.matches[k] == nil || s.matches[k-1].start > s.matches[k].start):
+			s.matches[k] = &matchNode{index: i, start: s.matches[k-1].start, prev: s.matches[k-1]}
+		default:
+			continue
+		}
+
+		if k == last && (s.best == nil || i-s.matches[k].start < s.best.index-s.best.start) {
+			s.best, changed = s.matches[k], true
+		}
+	}
+
+	return changed
+}
+
+// done reports whether no later element can improve the best match.
+func (s *minimumDistanceState) done() bool {
+	return s.best != nil && s.best.index-s.best.start == len(s.needle)-1
+}
+
+// result returns the best match, or nil.
+func (s *minimumDistanceState) result() []int {
+	if s.best == nil {
+		return nil
+	}
+
+	result := make([]int, len(s.needle))
+	for k, node := len(s.needle)-1, s.best; node != nil; k, node = k-1, node.prev {
+		result[k] = node.index
+	}
+
+	return result
+}
+
+func streamResult(result []int, count, needleLen int, err error) ([]int, error) {
+	switch {
+	case err != nil:
+		return nil, err
+	case count == 0:
+		return nil, ErrHaystackEmpty
+	case count < needleLen:
+		return nil, ErrHaystackShorter
+	case len(result) < needleLen:
+		return []int{}, nil
+	}
+
+	return result, nil
+}
+
+func seqStream(seq Seq[int]) stream {
+	return func(yield func(int) bool) error {
+		seq(yield)
+		return nil
+	}
+}
+
+func readerStream(r io.Reader) stream {
+	return func(yield func(int) bool) error {
+		scanner := bufio.NewScanner(r)
+		scanner.Split(scanElements)
+		for scanner.Scan() {
+			token := strings.TrimSpace(scanner.Text())
+			if len(token) == 0 {
+				continue
+			}
+
+			number, err := strconv.Atoi(token)
+			if err != nil {
+				return wrapErr(ErrInvalidElement, token)
+			}
+
+			if !yield(number) {
+				return nil
+			}
+		}
+
+		return scanner.Err()
+	}
+}
+
+func scanElements(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, ",\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+
+	return 0, nil, nil
+}
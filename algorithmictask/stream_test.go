@@ -0,0 +1,193 @@
+package algorithmictask
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamSearch(t *testing.T) {
+	haystack := "662, 154063\n38,1\n946773,7877907760054\r\n332,76826670,7653639346039,90593,2567954972664\n"
+	needle := []int{6, 5, 4}
+
+	actual, err := FirstFromReader(strings.NewReader(haystack), needle)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1, 4}, actual)
+
+	actual, err = FirstWithMaxDistanceFromReader(strings.NewReader(haystack), needle, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{7, 8, 10}, actual)
+
+	actual, err = FirstWithMinDistanceFromReader(strings.NewReader(haystack), needle)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{8, 9, 10}, actual)
+}
+
+func TestStreamSearchErrors(t *testing.T) {
+	errRead := errors.New("read error")
+
+	for _, s := range []struct {
+		name          string
+		haystack      string
+		needle        []int
+		maxDistance   int
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:        "no_results",
+			haystack:    "1,1",
+			needle:      []int{2},
+			maxDistance: 1,
+			expected:    []int{},
+		},
+		{
+			name:          "haystack_is_empty",
+			haystack:      "\n",
+			needle:        []int{0},
+			maxDistance:   1,
+			expectedError: ErrHaystackEmpty,
+		},
+		{
+			name:          "needle_is_empty",
+			haystack:      "0",
+			maxDistance:   1,
+			expectedError: ErrNeedleEmpty,
+		},
+		{
+			name:          "haystack_is_shorter",
+			haystack:      "123",
+			needle:        []int{1, 2},
+			maxDistance:   1,
+			expectedError: ErrHaystackShorter,
+		},
+		{
+			name:          "invalid_element",
+			haystack:      "1,x,2",
+			needle:        []int{1, 2},
+			maxDistance:   1,
+			expectedError: ErrInvalidElement,
+		},
+		{
+			name:          "read_error",
+			needle:        []int{1},
+			maxDistance:   1,
+			expectedError: errRead,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			reader := func() *strings.Reader { return strings.NewReader(s.haystack) }
+			if s.expectedError == errRead {
+				for _, find := range []func() ([]int, error){
+					func() ([]int, error) { return FirstFromReader(iotest.ErrReader(errRead), s.needle) },
+					func() ([]int, error) {
+						return FirstWithMaxDistanceFromReader(iotest.ErrReader(errRead), s.needle, s.maxDistance)
+					},
+					func() ([]int, error) { return FirstWithMinDistanceFromReader(iotest.ErrReader(errRead), s.needle) },
+				} {
+					_, err := find()
+					assert.ErrorIs(t, err, errRead)
+				}
+				return
+			}
+
+			actual, err := FirstFromReader(reader(), s.needle)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+
+			actual, err = FirstWithMaxDistanceFromReader(reader(), s.needle, s.maxDistance)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+
+			actual, err = FirstWithMinDistanceFromReader(reader(), s.needle)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+
+	_, err := FirstWithMaxDistanceFromReader(strings.NewReader("1"), []int{1}, 0)
+	assert.ErrorIs(t, err, ErrDistanceMustBePositive)
+}
+
+func TestStreamSearchStopsAtMatch(t *testing.T) {
+	var read int
+	haystack := func(yield func(int) bool) {
+		for i := 0; i < 100; i++ {
+			read++
+			if !yield(i % 10) {
+				return
+			}
+		}
+	}
+
+	for _, s := range []struct {
+		name     string
+		find     func() ([]int, error)
+		expected []int
+		read     int
+	}{
+		{
+			name:     "first",
+			find:     func() ([]int, error) { return FirstFromSeq(haystack, []int{3, 1}) },
+			expected: []int{3, 11},
+			read:     12,
+		},
+		{
+			name:     "max_distance",
+			find:     func() ([]int, error) { return FirstWithMaxDistanceFromSeq(haystack, []int{9, 1}, 2) },
+			expected: []int{9, 11},
+			read:     12,
+		},
+		{
+			name:     "min_distance",
+			find:     func() ([]int, error) { return FirstWithMinDistanceFromSeq(haystack, []int{4, 5}) },
+			expected: []int{4, 5},
+			read:     6,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			read = 0
+			actual, err := s.find()
+			assert.NoError(t, err)
+			assert.Equal(t, s.expected, actual)
+			assert.Equal(t, s.read, read)
+		})
+	}
+}
+
+func TestStreamSearchMatchesSearch(t *testing.T) {
+	for _, c := range (randomCases{n: 500, maxLen: 30, maxNeedle: 30}).generate() {
+		haystack, needle, maxDistance := c.haystack, c.needle, c.maxDistance
+		elements := make([]string, len(haystack))
+		for i := range haystack {
+			elements[i] = strconv.Itoa(haystack[i])
+		}
+		seq := func(yield func(int) bool) {
+			for _, number := range haystack {
+				if !yield(number) {
+					return
+				}
+			}
+		}
+		search := mustSearch(t, haystack)
+
+		expected, _ := search.First(needle)
+		actual, err := FirstFromSeq(seq, needle)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual)
+
+		expected, _ = search.FirstWithMaxDistance(needle, maxDistance)
+		actual, err = FirstWithMaxDistanceFromReader(strings.NewReader(strings.Join(elements, "\n")), needle, maxDistance)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v, maxDistance: %d", haystack, needle, maxDistance)
+
+		expected, _ = search.FirstWithMinDistance(needle)
+		actual, err = FirstWithMinDistanceFromSeq(seq, needle)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, actual, "haystack: %v, needle: %v", haystack, needle)
+	}
+}
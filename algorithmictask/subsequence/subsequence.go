@@ -0,0 +1,85 @@
+package subsequence
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Haystack is a haystack of any kind which the needle elements of type N are matched against.
+type Haystack[N any] interface {
+	Len() int
+	// Next returns the lowest index from the given one containing the element, or Len().
+	Next(from int, element N) int
+}
+
+// Search yields the lowest indexes of the haystack matching the needle in order, once for each
+// start index in ascending order, until yield returns false like an iter.Seq. It yields nothing
+// for an empty needle.
+func Search[T, N any](haystack []T, needle []N, match func(T, N) bool) func(yield func([]int) bool) {
+	return All[N](sliceHaystack[T, N]{haystack, match}, needle)
+}
+
+// All is like Search for any Haystack.
+func All[N any](haystack Haystack[N], needle []N) func(yield func([]int) bool) {
+	return func(yield func([]int) bool) {
+		if len(needle) == 0 {
+			return
+		}
+
+		for start := haystack.Next(0, needle[0]); start < haystack.Len(); start = haystack.Next(start+1, needle[0]) {
+			result := From(haystack, needle, start)
+			if len(result) == 0 || !yield(result) {
+				return
+			}
+		}
+	}
+}
+
+// From returns the lowest indexes from start matching the needle in order, or an empty slice.
+func From[N any](haystack Haystack[N], needle []N, start int) []int {
+	result := make([]int, len(needle))
+	for k, element := range needle {
+		start = haystack.Next(start, element)
+		if start >= haystack.Len() {
+			return []int{}
+		}
+
+		result[k] = start
+		start++
+	}
+
+	return result
+}
+
+// Digits matches the numbers whose decimal digits, ignoring the sign, contain the needle element
+// as a substring, so 54 matches -1543.
+func Digits(number int, element string) bool {
+	return strings.Contains(strings.TrimPrefix(strconv.Itoa(number), "-"), element)
+}
+
+// Substring matches the strings containing the needle element.
+func Substring(s, substring string) bool {
+	return strings.Contains(s, substring)
+}
+
+// Predicate matches the elements the needle element returns true for.
+func Predicate[T any](element T, predicate func(T) bool) bool {
+	return predicate(element)
+}
+
+type sliceHaystack[T, N any] struct {
+	elements []T
+	match    func(T, N) bool
+}
+
+func (h sliceHaystack[T, N]) Len() int {
+	return len(h.elements)
+}
+
+func (h sliceHaystack[T, N]) Next(from int, element N) int {
+	for from < len(h.elements) && !h.match(h.elements[from], element) {
+		from++
+	}
+
+	return from
+}
@@ -0,0 +1,54 @@
+package subsequence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collect(seq func(yield func([]int) bool)) [][]int {
+	results := [][]int{}
+	seq(func(result []int) bool {
+		results = append(results, result)
+		return true
+	})
+
+	return results
+}
+
+func TestSearch(t *testing.T) {
+	assert.Equal(t, [][]int{{0, 1, 4}, {1, 5, 8}, {4, 5, 8}, {5, 8, 10}, {7, 8, 10}, {8, 9, 10}}, collect(Search(
+		[]int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664},
+		[]string{"6", "5", "4"},
+		Digits,
+	)))
+
+	lines := []string{"boot", "disk full", "retry", "disk full", "shutdown"}
+	assert.Equal(t, [][]int{{1, 2, 4}}, collect(Search(lines, []string{"disk", "retry", "shut"}, Substring)))
+
+	type event struct {
+		level string
+		code  int
+	}
+	events := []event{{"info", 1}, {"error", 500}, {"info", 2}, {"error", 404}}
+	isError := func(e event) bool { return e.level == "error" }
+	isClientError := func(e event) bool { return e.code >= 400 && e.code < 500 }
+	assert.Equal(t, [][]int{{1, 3}}, collect(Search(events, []func(event) bool{isError, isClientError}, Predicate[event])))
+
+	assert.Equal(t, [][]int{}, collect(Search([]int{1, 2}, nil, Digits)))
+	assert.Equal(t, [][]int{}, collect(Search([]int{1, 2}, []string{"2", "1"}, Digits)))
+}
+
+func TestSearchStops(t *testing.T) {
+	var results [][]int
+	Search([]int{1, 1, 1, 1}, []string{"1"}, Digits)(func(result []int) bool {
+		results = append(results, result)
+		return len(results) < 2
+	})
+	assert.Equal(t, [][]int{{0}, {1}}, results)
+}
+
+func TestDigits(t *testing.T) {
+	assert.True(t, Digits(-154, "54"))
+	assert.False(t, Digits(154, "45"))
+}
@@ -0,0 +1,175 @@
+package algorithmictask
+
+import (
+	"cmp"
+	"container/heap"
+	"errors"
+	"slices"
+	"strconv"
+)
+
+var ErrInvalidTopK = errors.New("k must be positive")
+
+// TopWindows returns up to k matches with distinct first and last indexes, ranked by distance and
+// then by start index, each with the lowest indexes in between. With nonOverlapping the windows
+// are picked greedily in that order, skipping those overlapping an already picked one.
+func (s *Search) TopWindows(needle []int, k int, nonOverlapping bool) ([][]int, error) {
+	if k <= 0 {
+		return nil, wrapErr(ErrInvalidTopK, strconv.Itoa(k))
+	}
+
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findTopWindows(s.matcher(), elements, k, nonOverlapping)
+}
+
+// findTopWindows ranks the shortest window of every start. A start has a window for every later
+// end matching the last needle element too, but each of them ranks below the shortest one, so
+// the top k windows only use the starts of the top k shortest ones, and the next end of a start
+// is only looked up once its window is popped. Picking non-overlapping windows only needs the
+// shortest ones, as any longer window contains the shortest one of its start, but a skipped
+// window does not count towards k, so all of them are kept.
+func findTopWindows(haystack matcher, needle []string, k int, nonOverlapping bool) ([][]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	candidates := windowHeap{}
+	bounded := worstFirst{&candidates}
+	shortestEnds(haystack, needle, func(w window) {
+		switch {
+		case nonOverlapping || len(candidates) < k:
+			candidates = append(candidates, w)
+			if !nonOverlapping {
+				heap.Fix(bounded, len(candidates)-1)
+			}
+		case w.before(candidates[0]):
+			candidates[0] = w
+			heap.Fix(bounded, 0)
+		}
+	})
+	heap.Init(&candidates)
+
+	last := len(needle) - 1
+	var picked []window
+	windows := [][]int{}
+	for len(windows) < k && len(candidates) > 0 {
+		w := heap.Pop(&candidates).(window)
+		if nonOverlapping {
+			i, overlaps := overlapping(picked, w)
+			if overlaps {
+				continue
+			}
+			picked = slices.Insert(picked, i, w)
+		}
+
+		if w.match == nil {
+			w.match = findFrom(haystack, needle, w.start)
+		}
+		windows = append(windows, w.match)
+
+		if nonOverlapping || last == 0 {
+			continue
+		}
+
+		if end := haystack.next(w.end+1, needle[last]); end < haystack.len() {
+			match := slices.Clone(w.match)
+			match[last] = end
+			heap.Push(&candidates, window{start: w.start, end: end, match: match})
+		}
+	}
+
+	return windows, nil
+}
+
+// shortestEnds visits the shortest window of every start, from the last start to the first.
+func shortestEnds(haystack matcher, needle []string, visit func(window)) {
+	// ends[k] is the lowest end of a match of needle[k:] starting at or after the current index,
+	// so each needle element is checked once per haystack element.
+	ends := make([]int, len(needle))
+	for k := range ends {
+		ends[k] = haystack.len()
+	}
+
+	last := len(needle) - 1
+	for i := haystack.len() - 1; i >= 0; i-- {
+		for k := 0; k <= last; k++ {
+			if !haystack.contains(i, needle[k]) {
+				continue
+			}
+
+			if k == last {
+				ends[k] = i
+			} else {
+				ends[k] = ends[k+1]
+			}
+
+			if k == 0 && ends[k] < haystack.len() {
+				visit(window{start: i, end: ends[k]})
+			}
+		}
+	}
+}
+
+// overlapping reports whether the window overlaps one of the picked windows, sorted by start,
+// and where it would be inserted among them.
+func overlapping(picked []window, w window) (int, bool) {
+	i, _ := slices.BinarySearchFunc(picked, w.start, func(p window, start int) int {
+		return cmp.Compare(p.start, start)
+	})
+
+	return i, (i > 0 && picked[i-1].end >= w.start) || (i < len(picked) && picked[i].start <= w.end)
+}
+
+// window is a match by its first and last index, with its indexes once they are known.
+type window struct {
+	start, end int
+	match      []int
+}
+
+// before ranks windows by distance and then by start index.
+func (w window) before(other window) bool {
+	if w.end-w.start != other.end-other.start {
+		return w.end-w.start < other.end-other.start
+	}
+
+	return w.start < other.start
+}
+
+// windowHeap pops the best ranked window first.
+type windowHeap []window
+
+func (h windowHeap) Len() int {
+	return len(h)
+}
+
+func (h windowHeap) Less(i, j int) bool {
+	return h[i].before(h[j])
+}
+
+func (h windowHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+func (h *windowHeap) Push(x any) {
+	*h = append(*h, x.(window))
+}
+
+func (h *windowHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// worstFirst keeps the worst ranked window on top, to replace it with better ones.
+type worstFirst struct {
+	*windowHeap
+}
+
+func (h worstFirst) Less(i, j int) bool {
+	return h.windowHeap.Less(j, i)
+}
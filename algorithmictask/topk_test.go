@@ -0,0 +1,169 @@
+package algorithmictask
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"solvencyanalytics/algorithmictask/subsequence"
+)
+
+func TestTopWindows(t *testing.T) {
+	for _, s := range []struct {
+		name           string
+		haystack       []int
+		needle         []int
+		k              int
+		nonOverlapping bool
+		expected       [][]int
+		expectedError  error
+	}{
+		{
+			name:     "ranked_by_distance_then_start",
+			haystack: []int{16, 2, 1, 12, 7, 1, 2},
+			needle:   []int{1, 2},
+			k:        4,
+			expected: [][]int{{0, 1}, {2, 3}, {5, 6}, {0, 3}},
+		},
+		{
+			name:     "later_ends_of_a_start",
+			haystack: []int{1, 9, 2, 2, 2},
+			needle:   []int{1, 9, 2},
+			k:        5,
+			expected: [][]int{{0, 1, 2}, {0, 1, 3}, {0, 1, 4}},
+		},
+		{
+			name:           "non_overlapping",
+			haystack:       []int{16, 2, 1, 12, 7, 1, 2},
+			needle:         []int{1, 2},
+			k:              4,
+			nonOverlapping: true,
+			expected:       [][]int{{0, 1}, {2, 3}, {5, 6}},
+		},
+		{
+			name:           "overlapping_window_skipped",
+			haystack:       []int{1, 7, 1, 2, 9, 9, 2},
+			needle:         []int{1, 2},
+			k:              3,
+			nonOverlapping: true,
+			expected:       [][]int{{2, 3}},
+		},
+		{
+			name:     "single_element",
+			haystack: []int{3, 1, 3},
+			needle:   []int{3},
+			k:        3,
+			expected: [][]int{{0}, {2}},
+		},
+		{
+			name:     "no_results",
+			haystack: []int{1, 2, 3},
+			needle:   []int{3, 1},
+			k:        1,
+			expected: [][]int{},
+		},
+		{
+			name:          "invalid_k",
+			haystack:      []int{1, 2, 3},
+			needle:        []int{1},
+			expectedError: ErrInvalidTopK,
+		},
+		{
+			name:          "validation_error",
+			haystack:      []int{1, 2, 3},
+			k:             1,
+			expectedError: ErrNeedleEmpty,
+		},
+		{
+			name:          "negative_needle",
+			haystack:      []int{1, 2, 3},
+			needle:        []int{-1},
+			k:             1,
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "haystack_shorter",
+			haystack:      []int{1},
+			needle:        []int{1, 1},
+			k:             1,
+			expectedError: ErrHaystackShorter,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := mustSearch(t, s.haystack).TopWindows(s.needle, s.k, s.nonOverlapping)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestTopWindowsOracle(t *testing.T) {
+	for _, c := range (randomCases{n: 1000, maxLen: 12, maxNeedle: 12}).generate() {
+		haystack, needle := c.haystack, c.needle
+		k := 1 + c.rnd.Intn(10)
+
+		// Matches are walked in lexicographic order, so the first one of each window has the
+		// lowest indexes.
+		windows := [][]int{}
+		var walk func(result []int, from int)
+		walk = func(result []int, from int) {
+			if len(result) == len(needle) {
+				if !slices.ContainsFunc(windows, func(window []int) bool {
+					return window[0] == result[0] && window[len(window)-1] == result[len(result)-1]
+				}) {
+					windows = append(windows, slices.Clone(result))
+				}
+				return
+			}
+			for i := from; i < len(haystack); i++ {
+				if subsequence.Digits(haystack[i], strconv.Itoa(needle[len(result)])) {
+					walk(append(result, i), i+1)
+				}
+			}
+		}
+		walk(nil, 0)
+		slices.SortStableFunc(windows, func(a, b []int) int {
+			return (a[len(a)-1] - a[0]) - (b[len(b)-1] - b[0])
+		})
+
+		top := windows[:min(k, len(windows))]
+		nonOverlapping := [][]int{}
+		for _, window := range windows {
+			if len(nonOverlapping) < k && !slices.ContainsFunc(nonOverlapping, func(other []int) bool {
+				return window[0] <= other[len(other)-1] && other[0] <= window[len(window)-1]
+			}) {
+				nonOverlapping = append(nonOverlapping, window)
+			}
+		}
+
+		search := mustSearch(t, haystack, WithIndex())
+		actual, err := search.TopWindows(needle, k, false)
+		assert.NoError(t, err)
+		assert.Equal(t, top, actual, "haystack: %v, needle: %v, k: %d", haystack, needle, k)
+
+		actual, err = search.TopWindows(needle, k, true)
+		assert.NoError(t, err)
+		assert.Equal(t, nonOverlapping, actual, "haystack: %v, needle: %v, k: %d", haystack, needle, k)
+	}
+}
+
+func BenchmarkTopWindows(b *testing.B) {
+	for _, n := range []int{10_000, 20_000, 40_000} {
+		haystack := make([]int, n)
+		for i := range haystack {
+			haystack[i] = 6
+			if i >= n/2 {
+				haystack[i] = 1
+			}
+		}
+		haystack[n-1] = 5
+		search := mustSearch(b, haystack)
+
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				search.TopWindows([]int{6, 1, 5}, 1, false)
+			}
+		})
+	}
+}
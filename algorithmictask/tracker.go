@@ -0,0 +1,145 @@
+package algorithmictask
+
+import "solvencyanalytics/algorithmictask/subsequence"
+
+// Matches holds the answers of a Tracker, each an empty slice until there is a match.
+type Matches struct {
+	First, MaxDistance, MinDistance []int
+}
+
+// Tracker maintains the answers of First, FirstWithMaxDistance and FirstWithMinDistance for an
+// append-only decimal haystack, without keeping more than maxDistance+1 of its elements. Each
+// append costs amortised O(len(needle)), plus O(maxDistance) once when the max distance match
+// is found.
+type Tracker struct {
+	needle      []string
+	maxDistance int
+	onChange    func(Matches)
+	count       int
+
+	first []int
+
+	// groups holds the start candidates of the max distance match which share their progress
+	// in the needle, ordered by start, so the progress decreases from group to group.
+	groups []distanceGroup
+	// recent holds the last maxDistance+1 elements, starting at index count-len(recent).
+	recent          []int
+	withMaxDistance []int
+	withMinDistance *minimumDistanceState
+}
+
+// distanceGroup holds start candidates with the same number of needle elements matched from
+// them greedily, so they match the rest at the same indexes.
+type distanceGroup struct {
+	starts  []int
+	matched int
+}
+
+// NewTracker returns a Tracker for a non-negative needle and maxDistance. onChange, unless nil,
+// is called with the new answers after each Append changing any of them.
+func NewTracker(needle []int, maxDistance int, onChange func(Matches)) (*Tracker, error) {
+	elements, err := decimalNeedle(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(elements) == 0 {
+		return nil, ErrNeedleEmpty
+	}
+
+	if maxDistance <= 0 {
+		return nil, ErrDistanceMustBePositive
+	}
+
+	return &Tracker{
+		needle:          elements,
+		maxDistance:     maxDistance,
+		onChange:        onChange,
+		withMinDistance: newMinimumDistanceState(elements),
+	}, nil
+}
+
+func (t *Tracker) Append(values ...int) {
+	changed := false
+	for _, number := range values {
+		changed = t.add(number) || changed
+	}
+
+	if changed && t.onChange != nil {
+		t.onChange(t.Matches())
+	}
+}
+
+func (t *Tracker) Matches() Matches {
+	matches := Matches{First: []int{}, MaxDistance: []int{}, MinDistance: []int{}}
+	if len(t.first) == len(t.needle) {
+		matches.First = append(matches.First, t.first...)
+	}
+	matches.MaxDistance = append(matches.MaxDistance, t.withMaxDistance...)
+	matches.MinDistance = append(matches.MinDistance, t.withMinDistance.result()...)
+
+	return matches
+}
+
+func (t *Tracker) add(number int) bool {
+	i := t.count
+	t.count++
+
+	changed := false
+	if len(t.first) < len(t.needle) && subsequence.Digits(number, t.needle[len(t.first)]) {
+		t.first = append(t.first, i)
+		changed = len(t.first) == len(t.needle)
+	}
+
+	if t.withMaxDistance == nil {
+		changed = t.addWithMaxDistance(i, number) || changed
+	}
+
+	return t.withMinDistance.add(i, number) || changed
+}
+
+// addWithMaxDistance advances the start candidates, and reports whether the earliest one
+// matched the whole needle within maxDistance. As the earlier candidates are gone by then, it
+// is the final answer.
+func (t *Tracker) addWithMaxDistance(i, number int) bool {
+	t.recent = append(t.recent, number)
+	if len(t.recent)-1 > t.maxDistance {
+		t.recent = t.recent[1:]
+	}
+
+	for g := range t.groups {
+		if subsequence.Digits(number, t.needle[t.groups[g].matched]) {
+			t.groups[g].matched++
+		}
+	}
+
+	if subsequence.Digits(number, t.needle[0]) {
+		t.groups = append(t.groups, distanceGroup{starts: []int{i}, matched: 1})
+	}
+
+	merged := t.groups[:0]
+	for _, group := range t.groups {
+		if last := len(merged) - 1; last >= 0 && merged[last].matched == group.matched {
+			merged[last].starts = append(merged[last].starts, group.starts...)
+		} else {
+			merged = append(merged, group)
+		}
+	}
+	t.groups = merged
+
+	if len(t.groups) > 0 && t.groups[0].matched == len(t.needle) {
+		start := t.groups[0].starts[0]
+		t.withMaxDistance = findInWindow(t.recent[start-(t.count-len(t.recent)):], t.needle, start, make([]int, 0, len(t.needle)))
+		t.groups, t.recent = nil, nil
+		return true
+	}
+
+	// The candidates which did not match the needle within maxDistance are dropped.
+	for len(t.groups) > 0 && i-t.groups[0].starts[0] >= t.maxDistance {
+		if t.groups[0].starts = t.groups[0].starts[1:]; len(t.groups[0].starts) == 0 {
+			t.groups = t.groups[1:]
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package algorithmictask
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker(t *testing.T) {
+	var changes []Matches
+	tracker, err := NewTracker([]int{6, 5, 4}, 3, func(matches Matches) {
+		changes = append(changes, matches)
+	})
+	require.NoError(t, err)
+
+	tracker.Append(662, 154063, 38)
+	assert.Empty(t, changes)
+
+	tracker.Append(1, 946773)
+	assert.Equal(t, []Matches{{First: []int{0, 1, 4}, MaxDistance: []int{}, MinDistance: []int{0, 1, 4}}}, changes)
+
+	tracker.Append(7877907760054, 332, 76826670)
+	assert.Len(t, changes, 1)
+
+	tracker.Append(7653639346039, 90593, 2567954972664)
+	assert.Equal(t, Matches{First: []int{0, 1, 4}, MaxDistance: []int{7, 8, 10}, MinDistance: []int{8, 9, 10}}, changes[1])
+	assert.Equal(t, changes[1], tracker.Matches())
+
+	// math.MaxInt means no limit on the distance.
+	tracker, err = NewTracker([]int{1, 2}, math.MaxInt, nil)
+	require.NoError(t, err)
+	tracker.Append(1, 2)
+	assert.Equal(t, Matches{First: []int{0, 1}, MaxDistance: []int{0, 1}, MinDistance: []int{0, 1}}, tracker.Matches())
+
+	_, err = NewTracker(nil, 3, nil)
+	assert.ErrorIs(t, err, ErrNeedleEmpty)
+
+	_, err = NewTracker([]int{-6}, 3, nil)
+	assert.ErrorIs(t, err, ErrNeedleNegative)
+
+	_, err = NewTracker([]int{6}, 0, nil)
+	assert.ErrorIs(t, err, ErrDistanceMustBePositive)
+}
+
+func TestTrackerMatchesSearch(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for n := 0; n < 300; n++ {
+		needle := make([]int, 1+rnd.Intn(4))
+		for i := range needle {
+			needle[i] = rnd.Intn(10)
+		}
+		maxDistance := 1 + rnd.Intn(8)
+
+		var changed bool
+		tracker, err := NewTracker(needle, maxDistance, func(Matches) { changed = true })
+		require.NoError(t, err)
+
+		var haystack []int
+		previous := tracker.Matches()
+		for len(haystack) < 40 {
+			values := make([]int, 1+rnd.Intn(3))
+			for i := range values {
+				values[i] = rnd.Intn(100)
+			}
+			haystack = append(haystack, values...)
+			changed = false
+			tracker.Append(values...)
+
+			search := mustSearch(t, haystack)
+			expected := Matches{First: []int{}, MaxDistance: []int{}, MinDistance: []int{}}
+			if len(haystack) >= len(needle) {
+				expected.First, _ = search.First(needle)
+				expected.MinDistance, _ = search.FirstWithMinDistance(needle)
+				expected.MaxDistance, _ = findFirstOccuranceWithMaxDistanceLimit(search.matcher(), digitStrings(needle), min(maxDistance, len(haystack)))
+			}
+
+			actual := tracker.Matches()
+			assert.Equal(t, expected, actual, "haystack: %v, needle: %v, maxDistance: %d", haystack, needle, maxDistance)
+			assert.Equal(t, changed, !assert.ObjectsAreEqual(previous, actual))
+			previous = actual
+		}
+	}
+}
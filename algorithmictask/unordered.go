@@ -0,0 +1,133 @@
+package algorithmictask
+
+import "slices"
+
+// FirstUnorderedWithMinDistance returns the smallest window of the haystack containing every
+// needle element in any order, as a distinct index for each needle element, so the needle
+// [5, 5] needs two elements containing 5. On a tie the window with the lowest start wins, and
+// equal needle elements take their indexes in ascending order.
+func (s *Search) FirstUnorderedWithMinDistance(needle []int) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstUnorderedOccuranceWithMinimumPossibleDistance(s.matcher(), elements)
+}
+
+func findFirstUnorderedOccuranceWithMinimumPossibleDistance(haystack matcher, needle []string) ([]int, error) {
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	// As a haystack element can contain several needle elements, the needle elements are matched
+	// to the window by augmenting paths, and the window shrinks while all of them are matched.
+	w := newWindowMatching(haystack, needle)
+	var result []int
+	minDistance := haystack.len()
+	for right := 0; right < haystack.len(); right++ {
+		w.right = right
+		for p := range needle {
+			if w.indexes[p] < 0 {
+				w.augment(p)
+			}
+		}
+
+		for w.matched == len(needle) {
+			if right-w.left < minDistance {
+				result, minDistance = slices.Clone(w.indexes), right-w.left
+			}
+			w.shrink()
+		}
+	}
+
+	if result == nil {
+		return []int{}, nil
+	}
+
+	// Equal needle elements can swap their indexes, so they are sorted.
+	for p := range needle {
+		for q := p + 1; q < len(needle); q++ {
+			if needle[p] == needle[q] && result[q] < result[p] {
+				result[p], result[q] = result[q], result[p]
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// windowMatching matches each needle element to a distinct index between left and right.
+type windowMatching struct {
+	// positions[p] holds the indexes containing needle[p] in ascending order.
+	positions   [][]int
+	left, right int
+	// indexes[p] is the index matched to needle[p] and owners[i] is the needle element matched
+	// to index i, or -1.
+	indexes, owners []int
+	matched         int
+	// visited[i] equals visit if index i was visited by the current augmenting path search.
+	visited []int
+	visit   int
+}
+
+func newWindowMatching(haystack matcher, needle []string) *windowMatching {
+	w := &windowMatching{
+		positions: make([][]int, len(needle)),
+		indexes:   make([]int, len(needle)),
+		owners:    make([]int, haystack.len()),
+		visited:   make([]int, haystack.len()),
+	}
+	for p, element := range needle {
+		w.indexes[p] = -1
+		for i := haystack.next(0, element); i < haystack.len(); i = haystack.next(i+1, element) {
+			w.positions[p] = append(w.positions[p], i)
+		}
+	}
+	for i := range w.owners {
+		w.owners[i] = -1
+	}
+
+	return w
+}
+
+func (w *windowMatching) augment(p int) {
+	w.visit++
+	if w.augmentFrom(p) {
+		w.matched++
+	}
+}
+
+func (w *windowMatching) augmentFrom(p int) bool {
+	from, _ := slices.BinarySearch(w.positions[p], w.left)
+	for _, i := range w.positions[p][from:] {
+		if i > w.right {
+			break
+		}
+
+		if w.visited[i] == w.visit {
+			continue
+		}
+		w.visited[i] = w.visit
+
+		if w.owners[i] < 0 || w.augmentFrom(w.owners[i]) {
+			w.owners[i], w.indexes[p] = p, i
+			return true
+		}
+	}
+
+	return false
+}
+
+// shrink drops the left index from the window, and rematches its needle element if there was one.
+func (w *windowMatching) shrink() {
+	p := w.owners[w.left]
+	w.left++
+	if p < 0 {
+		return
+	}
+
+	w.owners[w.left-1], w.indexes[p] = -1, -1
+	w.matched--
+	w.augment(p)
+}
@@ -0,0 +1,123 @@
+package algorithmictask
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"solvencyanalytics/algorithmictask/subsequence"
+)
+
+func TestFirstUnorderedWithMinDistance(t *testing.T) {
+	for _, s := range []struct {
+		name          string
+		haystack      []int
+		needle        []int
+		expected      []int
+		expectedError error
+	}{
+		{
+			name:     "any_order",
+			haystack: []int{662, 154063, 38, 1, 946773, 7877907760054, 332, 76826670, 7653639346039, 90593, 2567954972664},
+			needle:   []int{4, 5, 6},
+			expected: []int{8, 9, 7},
+		},
+		{
+			name:     "reversed",
+			haystack: []int{4, 1, 4, 5, 6, 1, 6},
+			needle:   []int{6, 5, 4},
+			expected: []int{4, 3, 2},
+		},
+		{
+			name:     "multiplicity",
+			haystack: []int{5, 1, 1, 5, 55, 1, 5},
+			needle:   []int{5, 5},
+			expected: []int{3, 4},
+		},
+		{
+			name:     "element_with_several_needle_elements",
+			haystack: []int{56, 5, 1, 1, 6},
+			needle:   []int{5, 6},
+			expected: []int{1, 0},
+		},
+		{
+			name:     "no_results",
+			haystack: []int{5, 1, 1},
+			needle:   []int{5, 5},
+			expected: []int{},
+		},
+		{
+			name:          "needle_error",
+			haystack:      []int{5},
+			needle:        []int{-5},
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "validation_error",
+			haystack:      []int{5},
+			needle:        []int{5, 5},
+			expectedError: ErrHaystackShorter,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := mustSearch(t, s.haystack).FirstUnorderedWithMinDistance(s.needle)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+		})
+	}
+}
+
+func TestFirstUnorderedWithMinDistanceOracle(t *testing.T) {
+	for _, c := range (randomCases{n: 1000, maxLen: 14, maxNeedle: 5}).generate() {
+		haystack, needle := c.haystack, c.needle
+
+		// fits reports whether needle[p:] can take distinct unused indexes between l and r.
+		var fits func(p, l, r int, used []bool) bool
+		fits = func(p, l, r int, used []bool) bool {
+			if p == len(needle) {
+				return true
+			}
+			for i := l; i <= r; i++ {
+				if !used[i] && subsequence.Digits(haystack[i], strconv.Itoa(needle[p])) {
+					used[i] = true
+					ok := fits(p+1, l, r, used)
+					used[i] = false
+					if ok {
+						return true
+					}
+				}
+			}
+			return false
+		}
+		start, distance := -1, -1
+		for d := 0; d < len(haystack) && start < 0; d++ {
+			for l := 0; l+d < len(haystack) && start < 0; l++ {
+				if fits(0, l, l+d, make([]bool, len(haystack))) {
+					start, distance = l, d
+				}
+			}
+		}
+
+		actual, err := mustSearch(t, haystack, WithIndex()).FirstUnorderedWithMinDistance(needle)
+		assert.NoError(t, err)
+		if start < 0 {
+			assert.Equal(t, []int{}, actual, "haystack: %v, needle: %v", haystack, needle)
+			continue
+		}
+
+		if assert.Len(t, actual, len(needle), "haystack: %v, needle: %v", haystack, needle) {
+			assert.Equal(t, start, slices.Min(actual), "haystack: %v, needle: %v", haystack, needle)
+			assert.Equal(t, start+distance, slices.Max(actual), "haystack: %v, needle: %v", haystack, needle)
+			sorted := slices.Clone(actual)
+			slices.Sort(sorted)
+			assert.Len(t, slices.Compact(sorted), len(needle), "haystack: %v, needle: %v", haystack, needle)
+			for p, i := range actual {
+				assert.True(t, subsequence.Digits(haystack[i], strconv.Itoa(needle[p])), "haystack: %v, needle: %v", haystack, needle)
+				for q := p + 1; q < len(needle); q++ {
+					assert.False(t, needle[p] == needle[q] && actual[q] < i, "haystack: %v, needle: %v", haystack, needle)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package algorithmictask
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+)
+
+var ErrInvalidTieBreak = errors.New("invalid tie break")
+
+// TieBreak orders the matches sharing the smallest distance.
+type TieBreak int
+
+const (
+	// TieEarliestStart compares the indexes from the first one, so the lowest start comes first,
+	// as FirstWithMinDistance returns.
+	TieEarliestStart TieBreak = iota
+	// TieEarliestEnd compares the indexes from the last one, so the lowest end comes first.
+	TieEarliestEnd
+	// TieSmallestSum prefers the lowest sum of the indexes, and then TieEarliestStart.
+	TieSmallestSum
+	// TieLatest is the reverse of TieEarliestStart, so the highest start comes first.
+	TieLatest
+)
+
+func (t TieBreak) validate() error {
+	if t < TieEarliestStart || t > TieLatest {
+		return wrapErr(ErrInvalidTieBreak, strconv.Itoa(int(t)))
+	}
+
+	return nil
+}
+
+// FirstWithMinDistanceBy returns the first match of AllWithMinDistance without listing the rest.
+func (s *Search) FirstWithMinDistanceBy(needle []int, tie TieBreak) ([]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findFirstOccuranceWithMinimumPossibleDistanceBy(s.matcher(), elements, tie)
+}
+
+// AllWithMinDistance returns every match with the smallest distance, ordered by the tie break.
+// Every choice of the indexes between the first and the last one is a match of its own, so
+// there can be many more matches than windows.
+func (s *Search) AllWithMinDistance(needle []int, tie TieBreak) ([][]int, error) {
+	elements, err := s.needleStrings(needle)
+	if err != nil {
+		return nil, err
+	}
+
+	return findAllOccurancesWithMinimumPossibleDistance(s.matcher(), elements, tie)
+}
+
+func findFirstOccuranceWithMinimumPossibleDistanceBy(haystack matcher, needle []string, tie TieBreak) ([]int, error) {
+	if err := tie.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	starts := minimumDistanceStarts(haystack, needle, true)
+	if len(starts) == 0 {
+		return []int{}, nil
+	}
+
+	// The lowest match of the first window is at most as high at every index as any other
+	// match, so it comes first for every policy but TieLatest, which starts with the highest
+	// match of the last window.
+	if tie == TieLatest {
+		return highestMatch(haystack, needle, findFrom(haystack, needle, starts[len(starts)-1])), nil
+	}
+
+	return findFrom(haystack, needle, starts[0]), nil
+}
+
+func findAllOccurancesWithMinimumPossibleDistance(haystack matcher, needle []string, tie TieBreak) ([][]int, error) {
+	if err := tie.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := validate(haystack, needle); err != nil {
+		return nil, err
+	}
+
+	// The windows come by start and their matches in lexicographic order, as TieEarliestStart
+	// wants them.
+	matches := [][]int{}
+	for _, start := range minimumDistanceStarts(haystack, needle, true) {
+		lowest := findFrom(haystack, needle, start)
+		matches = appendWindowMatches(matches, haystack, needle, lowest)
+	}
+
+	switch tie {
+	case TieEarliestEnd:
+		slices.SortStableFunc(matches, compareFromEnd)
+	case TieSmallestSum:
+		slices.SortStableFunc(matches, func(a, b []int) int {
+			return cmp.Compare(indexSum(a), indexSum(b))
+		})
+	case TieLatest:
+		slices.Reverse(matches)
+	}
+
+	return matches, nil
+}
+
+// appendWindowMatches appends every match with the first and last index of the lowest one in
+// lexicographic order. As no match is shorter, every needle element placed up to its index in
+// the highest match leads to a match.
+func appendWindowMatches(matches [][]int, haystack matcher, needle []string, lowest []int) [][]int {
+	highest := highestMatch(haystack, needle, lowest)
+	match := slices.Clone(lowest)
+	var walk func(k int)
+	walk = func(k int) {
+		if k == len(needle) {
+			matches = append(matches, slices.Clone(match))
+			return
+		}
+
+		for i := haystack.next(match[k-1]+1, needle[k]); i <= highest[k]; i = haystack.next(i+1, needle[k]) {
+			match[k] = i
+			walk(k + 1)
+		}
+	}
+	walk(1)
+
+	return matches
+}
+
+// highestMatch returns the match with the first and last index of the lowest one at the highest
+// indexes, taking each element at the last index before the next one.
+func highestMatch(haystack matcher, needle []string, lowest []int) []int {
+	highest := slices.Clone(lowest)
+	for k := len(needle) - 2; k > 0; k-- {
+		i := highest[k+1] - 1
+		for !haystack.contains(i, needle[k]) {
+			i--
+		}
+		highest[k] = i
+	}
+
+	return highest
+}
+
+func compareFromEnd(a, b []int) int {
+	k := len(a) - 1
+	for k > 0 && a[k] == b[k] {
+		k--
+	}
+
+	return cmp.Compare(a[k], b[k])
+}
+
+func indexSum(match []int) int {
+	sum := 0
+	for _, i := range match {
+		sum += i
+	}
+
+	return sum
+}
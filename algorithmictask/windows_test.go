@@ -0,0 +1,179 @@
+package algorithmictask
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"solvencyanalytics/algorithmictask/subsequence"
+)
+
+func TestAllWithMinDistance(t *testing.T) {
+	for _, s := range []struct {
+		name          string
+		haystack      []int
+		needle        []int
+		tie           TieBreak
+		expected      [][]int
+		expectedError error
+	}{
+		{
+			name:     "one_match_per_window",
+			haystack: []int{16, 2, 1, 12, 7, 1, 2},
+			needle:   []int{1, 2},
+			tie:      TieEarliestEnd,
+			expected: [][]int{{0, 1}, {2, 3}, {5, 6}},
+		},
+		{
+			name:     "every_match_of_a_window",
+			haystack: []int{1, 2, 2, 3},
+			needle:   []int{1, 2, 3},
+			tie:      TieEarliestStart,
+			expected: [][]int{{0, 1, 3}, {0, 2, 3}},
+		},
+		{
+			name:     "earliest_start",
+			haystack: []int{1, 2, 23, 3, 3, 3, 34},
+			needle:   []int{1, 2, 3, 4},
+			tie:      TieEarliestStart,
+			expected: [][]int{{0, 1, 2, 6}, {0, 1, 3, 6}, {0, 1, 4, 6}, {0, 1, 5, 6}, {0, 2, 3, 6}, {0, 2, 4, 6}, {0, 2, 5, 6}},
+		},
+		{
+			name:     "earliest_end",
+			haystack: []int{1, 2, 23, 3, 3, 3, 34},
+			needle:   []int{1, 2, 3, 4},
+			tie:      TieEarliestEnd,
+			expected: [][]int{{0, 1, 2, 6}, {0, 1, 3, 6}, {0, 2, 3, 6}, {0, 1, 4, 6}, {0, 2, 4, 6}, {0, 1, 5, 6}, {0, 2, 5, 6}},
+		},
+		{
+			name:     "smallest_sum",
+			haystack: []int{1, 2, 23, 3, 3, 3, 34},
+			needle:   []int{1, 2, 3, 4},
+			tie:      TieSmallestSum,
+			expected: [][]int{{0, 1, 2, 6}, {0, 1, 3, 6}, {0, 1, 4, 6}, {0, 2, 3, 6}, {0, 1, 5, 6}, {0, 2, 4, 6}, {0, 2, 5, 6}},
+		},
+		{
+			name:     "latest",
+			haystack: []int{1, 9, 9, 2, 7, 1, 9, 9, 2},
+			needle:   []int{1, 9, 2},
+			tie:      TieLatest,
+			expected: [][]int{{5, 7, 8}, {5, 6, 8}, {0, 2, 3}, {0, 1, 3}},
+		},
+		{
+			name:     "no_results",
+			haystack: []int{1, 9, 9, 2},
+			needle:   []int{3},
+			tie:      TieLatest,
+			expected: [][]int{},
+		},
+		{
+			name:          "invalid_tie_break",
+			haystack:      []int{1},
+			needle:        []int{1},
+			tie:           TieBreak(4),
+			expectedError: ErrInvalidTieBreak,
+		},
+		{
+			name:          "needle_error",
+			haystack:      []int{1},
+			needle:        []int{-1},
+			expectedError: ErrNeedleNegative,
+		},
+		{
+			name:          "validation_error",
+			haystack:      []int{1},
+			expectedError: ErrNeedleEmpty,
+		},
+	} {
+		t.Run(s.name, func(t *testing.T) {
+			actual, err := mustSearch(t, s.haystack).AllWithMinDistance(s.needle, s.tie)
+			assert.Equal(t, s.expected, actual)
+			assert.ErrorIs(t, err, s.expectedError)
+
+			first, err := mustSearch(t, s.haystack, WithIndex()).FirstWithMinDistanceBy(s.needle, s.tie)
+			assert.ErrorIs(t, err, s.expectedError)
+			switch {
+			case s.expectedError != nil:
+				assert.Nil(t, first)
+			case len(s.expected) > 0:
+				assert.Equal(t, s.expected[0], first)
+			default:
+				assert.Equal(t, []int{}, first)
+			}
+		})
+	}
+}
+
+func TestAllWithMinDistanceOracle(t *testing.T) {
+	for _, c := range (randomCases{n: 1000, maxLen: 12, maxNeedle: 12}).generate() {
+		haystack, needle := c.haystack, c.needle
+
+		// Every match with the smallest distance in lexicographic order.
+		lowest := [][]int{}
+		minDistance := len(haystack)
+		var walk func(result []int, from int)
+		walk = func(result []int, from int) {
+			if len(result) == len(needle) {
+				switch distance := result[len(result)-1] - result[0]; {
+				case distance < minDistance:
+					minDistance = distance
+					lowest = [][]int{slices.Clone(result)}
+				case distance == minDistance:
+					lowest = append(lowest, slices.Clone(result))
+				}
+				return
+			}
+			for i := from; i < len(haystack); i++ {
+				if subsequence.Digits(haystack[i], strconv.Itoa(needle[len(result)])) {
+					walk(append(result, i), i+1)
+				}
+			}
+		}
+		walk(nil, 0)
+
+		expected := map[TieBreak][][]int{
+			TieEarliestStart: lowest,
+			TieEarliestEnd: sortedStable(lowest, func(a, b []int) bool {
+				return slices.Compare(reversedCopy(a), reversedCopy(b)) < 0
+			}),
+			TieSmallestSum: sortedStable(lowest, func(a, b []int) bool {
+				return indexSum(a) < indexSum(b)
+			}),
+			TieLatest: reversedCopy(lowest),
+		}
+
+		search := mustSearch(t, haystack)
+		for tie := TieEarliestStart; tie <= TieLatest; tie++ {
+			actual, err := search.AllWithMinDistance(needle, tie)
+			assert.NoError(t, err)
+			assert.Equal(t, expected[tie], actual, "haystack: %v, needle: %v, tie: %d", haystack, needle, tie)
+
+			first, err := search.FirstWithMinDistanceBy(needle, tie)
+			assert.NoError(t, err)
+			if len(expected[tie]) > 0 {
+				assert.Equal(t, expected[tie][0], first, "haystack: %v, needle: %v, tie: %d", haystack, needle, tie)
+			} else {
+				assert.Equal(t, []int{}, first)
+			}
+		}
+	}
+}
+
+// sortedStable sorts a copy with a stable insertion sort, independent of the package code.
+func sortedStable(matches [][]int, less func(a, b []int) bool) [][]int {
+	sorted := slices.Clone(matches)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && less(sorted[j], sorted[j-1]); j-- {
+			sorted[j], sorted[j-1] = sorted[j-1], sorted[j]
+		}
+	}
+
+	return sorted
+}
+
+func reversedCopy[T any](s []T) []T {
+	s = slices.Clone(s)
+	slices.Reverse(s)
+	return s
+}